@@ -0,0 +1,192 @@
+package emailforwardparser
+
+import (
+	"strings"
+
+	regexp "github.com/wasilibs/go-re2"
+)
+
+// _Alternation finds the pattern of a list matching closest to the top of a
+// text in a single scan, as _LoopRegexesMatch and _LoopRegexesSplit do with
+// highestPosition: the patterns are joined in one alternation, each wrapped
+// in the only group, telling which one matched. RE2 prefers the leftmost
+// match, then the first alternative, so both pick the same pattern.
+type _Alternation struct {
+	re      *regexp.Regexp
+	regexes []*regexp.Regexp
+}
+
+// _CompileAlternation returns nil if the alternation does not compile, such
+// as when it is too large for RE2, in which case the patterns are looped
+// over
+func _CompileAlternation(regexes []*regexp.Regexp) *_Alternation {
+	exprs := make([]string, 0, len(regexes))
+
+	for _, re := range regexes {
+		// The groups of the patterns are left out, as a name may only be
+		// used once and RE2 is faster with fewer groups; the groups are read
+		// from the pattern that matched
+		exprs = append(exprs, "("+_UncaptureGroups(re.String())+")")
+	}
+
+	re, err := regexp.Compile(strings.Join(exprs, "|"))
+	if err != nil || re.NumSubexp() != len(regexes) {
+		return nil
+	}
+
+	return &_Alternation{re: re, regexes: regexes}
+}
+
+// _Find returns the pattern that matched closest to the top of str
+func (alternation *_Alternation) _Find(str string) *regexp.Regexp {
+	loc := alternation.re.FindStringSubmatchIndex(str)
+	if loc == nil {
+		return nil
+	}
+
+	for i := range alternation.regexes {
+		if loc[2*i+2] >= 0 {
+			return alternation.regexes[i]
+		}
+	}
+
+	return nil
+}
+
+func (alternation *_Alternation) _Match(str string) ([]string, *regexp.Regexp) {
+	if re := alternation._Find(str); re != nil {
+		return re.FindStringSubmatch(str), re
+	}
+
+	return nil, nil
+}
+
+func (alternation *_Alternation) _Split(str string) ([]string, *regexp.Regexp) {
+	if re := alternation._Find(str); re != nil {
+		return splitWithRegexp(re, str), re
+	}
+
+	return nil, nil
+}
+
+// _UncaptureGroups turns the groups of expr, such as (…) or (?P<date>…),
+// into non-capturing ones
+func _UncaptureGroups(expr string) string {
+	builder := strings.Builder{}
+	class := false
+
+	for i := 0; i < len(expr); i++ {
+		switch {
+		case expr[i] == '\\' && i+1 < len(expr):
+			builder.WriteString(expr[i : i+2])
+			i++
+			continue
+		case class && strings.HasPrefix(expr[i:], "[:"):
+			if end := strings.Index(expr[i:], ":]"); end >= 0 {
+				builder.WriteString(expr[i : i+end+2])
+				i += end + 1
+				continue
+			}
+		case class:
+			class = expr[i] != ']'
+		case expr[i] == '[':
+			class = true
+
+			// A ] right after [ or [^ is a literal
+			if strings.HasPrefix(expr[i+1:], "^]") {
+				builder.WriteString("[^]")
+				i += 2
+				continue
+			} else if strings.HasPrefix(expr[i+1:], "]") {
+				builder.WriteString("[]")
+				i++
+				continue
+			}
+		case strings.HasPrefix(expr[i:], "(?P<"), strings.HasPrefix(expr[i:], "(?<"):
+			if end := strings.IndexByte(expr[i:], '>'); end >= 0 {
+				builder.WriteString("(?:")
+				i += end
+				continue
+			}
+		case expr[i] == '(' && !strings.HasPrefix(expr[i:], "(?"):
+			builder.WriteString("(?:")
+			continue
+		}
+
+		builder.WriteByte(expr[i])
+	}
+
+	return builder.String()
+}
+
+// _AlternationKey identifies a list of patterns by its first pattern and
+// length: the patterns of each field are compiled separately, so lists made
+// of whole fields are told apart
+type _AlternationKey struct {
+	first *regexp.Regexp
+	count int
+}
+
+func _NewAlternationKey(regexes []*regexp.Regexp) _AlternationKey {
+	return _AlternationKey{first: regexes[0], count: len(regexes)}
+}
+
+// _CompileAlternations compiles the alternation of each field, and of the
+// other lists of patterns the parser tries
+func (patterns *_Patterns) _CompileAlternations() {
+	patterns.Alternations = map[_AlternationKey]*_Alternation{}
+
+	lists := [][]*regexp.Regexp{patterns.OriginalSubjectAndLax, patterns.ReplyPrefixes}
+
+	for _, field := range patterns._Fields() {
+		lists = append(lists, *field)
+	}
+
+	for _, regexes := range lists {
+		if len(regexes) > 1 {
+			if alternation := _CompileAlternation(regexes); alternation != nil {
+				patterns.Alternations[_NewAlternationKey(regexes)] = alternation
+			}
+		}
+	}
+}
+
+func (patterns *_Patterns) _Alternation(regexes []*regexp.Regexp) *_Alternation {
+	if len(regexes) == 0 {
+		return nil
+	}
+
+	alternation := patterns.Alternations[_NewAlternationKey(regexes)]
+	if alternation == nil || &alternation.regexes[0] == &regexes[0] {
+		return alternation
+	}
+
+	// Another list with the same first pattern and length
+	for i, re := range alternation.regexes {
+		if re != regexes[i] {
+			return nil
+		}
+	}
+
+	return alternation
+}
+
+// _Match is _LoopRegexesMatch with highestPosition, in a single scan when the
+// list has an alternation
+func (patterns *_Patterns) _Match(regexes []*regexp.Regexp, str string) ([]string, *regexp.Regexp) {
+	if alternation := patterns._Alternation(regexes); alternation != nil {
+		return alternation._Match(str)
+	}
+
+	return _LoopRegexesMatch(regexes, str, true)
+}
+
+// _Split is _LoopRegexesSplit with highestPosition, in a single scan when the
+// list has an alternation
+func (patterns *_Patterns) _Split(regexes []*regexp.Regexp, str string) ([]string, *regexp.Regexp) {
+	if alternation := patterns._Alternation(regexes); alternation != nil {
+		return alternation._Split(str)
+	}
+
+	return _LoopRegexesSplit(regexes, str, true)
+}
@@ -0,0 +1,178 @@
+package emailforwardparser
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	regexp "github.com/wasilibs/go-re2"
+)
+
+// _LoopParser returns a copy of the parser that loops over the patterns
+func _LoopParser(parser *Parser) *Parser {
+	patterns := *parser.patterns
+	patterns.Alternations = nil
+
+	looping := *parser
+	looping.patterns = &patterns
+
+	return &looping
+}
+
+type _Fixture struct {
+	// The name of the body file, such as "gmail_en_body", followed by the
+	// name of its subject file when fixtures/pairs.csv pairs them, such as
+	// "outlook_live_body,outlook_live_fr_subject"
+	Name    string
+	Body    string
+	Subject string
+}
+
+// _ReadFixtures returns the bodies of the fixtures directory with their
+// subject, if any, then the bodies paired with another subject in
+// fixtures/pairs.csv
+func _ReadFixtures(tb testing.TB) []_Fixture {
+	paths, err := filepath.Glob("./fixtures/*_body*.txt")
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	fixtures := []_Fixture{}
+
+	for _, path := range paths {
+		body, err := os.ReadFile(path)
+		if err != nil {
+			tb.Fatal(err)
+		}
+
+		subject, _ := os.ReadFile(strings.Replace(path, "_body", "_subject", 1))
+
+		fixtures = append(fixtures, _Fixture{
+			Name:    strings.TrimSuffix(filepath.Base(path), ".txt"),
+			Body:    string(body),
+			Subject: string(subject),
+		})
+	}
+
+	pairs, err := os.ReadFile("./fixtures/pairs.csv")
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	for _, line := range strings.Fields(string(pairs)) {
+		names := strings.Split(line, ",")
+		if len(names) != 2 {
+			tb.Fatalf("fixtures/pairs.csv: %q is not a body and a subject", line)
+		}
+
+		body, err := os.ReadFile("./fixtures/" + names[0] + ".txt")
+		if err != nil {
+			tb.Fatal(err)
+		}
+
+		subject, err := os.ReadFile("./fixtures/" + names[1] + ".txt")
+		if err != nil {
+			tb.Fatal(err)
+		}
+
+		fixtures = append(fixtures, _Fixture{
+			Name:    line,
+			Body:    string(body),
+			Subject: string(subject),
+		})
+	}
+
+	return fixtures
+}
+
+// _ReadHTMLFixtures returns the HTML bodies of the fixtures directory, named
+// after their file such as "gmail_en_body.html"
+func _ReadHTMLFixtures(tb testing.TB) []_Fixture {
+	paths, err := filepath.Glob("./fixtures/*_body*.html")
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	fixtures := []_Fixture{}
+
+	for _, path := range paths {
+		body, err := os.ReadFile(path)
+		if err != nil {
+			tb.Fatal(err)
+		}
+
+		fixtures = append(fixtures, _Fixture{Name: filepath.Base(path), Body: string(body)})
+	}
+
+	return fixtures
+}
+
+func TestAlternations(t *testing.T) {
+	patterns := _DefaultParser.patterns
+
+	for _, field := range patterns._Fields() {
+		if len(*field) > 1 && patterns._Alternation(*field) == nil {
+			t.Error("no alternation for", patterns.Names[(*field)[0]])
+		}
+	}
+
+	if patterns._Alternation(patterns.OriginalSubject[1:]) != nil {
+		t.Error("unexpected alternation for part of a field")
+	}
+
+	if patterns._Alternation(append([]*regexp.Regexp{patterns.Separator[1]}, patterns.Separator[1:]...)) != nil {
+		t.Error("unexpected alternation for another list")
+	}
+}
+
+func TestAlternationsLoop(t *testing.T) {
+	looping := _LoopParser(_DefaultParser)
+
+	for _, fixture := range _ReadFixtures(t) {
+		for _, subject := range []string{"", fixture.Subject} {
+			result := _DefaultParser.ReadWithSpans(fixture.Body, subject)
+			expected := looping.ReadWithSpans(fixture.Body, subject)
+
+			if !reflect.DeepEqual(result, expected) {
+				t.Errorf("%s: the alternations and the loop differ:\n%+v\n%+v", fixture.Name, result, expected)
+			}
+		}
+	}
+}
+
+func TestUncaptureGroups(t *testing.T) {
+	for expr, expected := range map[string]string{
+		`(?P<date>.+)\s(?<from_name>.*)(?i:a)(b)`: `(?:.+)\s(?:.*)(?i:a)(?:b)`,
+		`\(?P<date>\(`:         `\(?P<date>\(`,
+		`[(?P<]x(?P<a>y)`:      `[(?P<]x(?:y)`,
+		`[](?P<]x`:             `[](?P<]x`,
+		`[^]\](]x(?P<a>y)`:     `[^]\](]x(?:y)`,
+		`[[:alpha:](](?P<a>y)`: `[[:alpha:](](?:y)`,
+	} {
+		if uncaptured := _UncaptureGroups(expr); uncaptured != expected {
+			t.Errorf("%s: unexpected %s, expected %s", expr, uncaptured, expected)
+		}
+	}
+}
+
+func BenchmarkRead(b *testing.B) {
+	fixtures := _ReadFixtures(b)
+
+	for _, benchmark := range []struct {
+		Name   string
+		Parser *Parser
+	}{
+		{"Alternations", _DefaultParser},
+		{"Loop", _LoopParser(_DefaultParser)},
+	} {
+		b.Run(benchmark.Name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				for _, fixture := range fixtures {
+					benchmark.Parser.Read(fixture.Body, fixture.Subject)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,478 @@
+package emailforwardparser
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// _BaselinePatterns are the default patterns as they were written in Go,
+// with the clients in their comments, before they moved to the locale files
+var _BaselinePatterns = map[string][]Pattern{
+	"Subject": {
+		{`(?m)^Fw:(.*)`, "Outlook Live / 365 (cs, en, hr, hu, sk), Yahoo Mail (all locales)"},
+		{`(?m)^VS:(.*)`, "Outlook Live / 365 (da), New Outlook 2019 (da)"},
+		{`(?m)^WG:(.*)`, "Outlook Live / 365 (de), New Outlook 2019 (de)"},
+		{`(?m)^RV:(.*)`, "Outlook Live / 365 (es), New Outlook 2019 (es)"},
+		{`(?m)^TR:(.*)`, "Outlook Live / 365 (fr), New Outlook 2019 (fr)"},
+		{`(?m)^I:(.*)`, "Outlook Live / 365 (it), New Outlook 2019 (it)"},
+		{`(?m)^FW:(.*)`, "Outlook Live / 365 (nl, pt), New Outlook 2019 (cs, en, hu, nl, pt, ru, sk), Outlook 2019 (all locales)"},
+		{`(?m)^Vs:(.*)`, "Outlook Live / 365 (no)"},
+		{`(?m)^PD:(.*)`, "Outlook Live / 365 (pl), New Outlook 2019 (pl)"},
+		{`(?m)^ENC:(.*)`, "Outlook Live / 365 (pt-br), New Outlook 2019 (pt-br)"},
+		{`(?m)^Redir.:(.*)`, "Outlook Live / 365 (ro)"},
+		{`(?m)^VB:(.*)`, "Outlook Live / 365 (sv), New Outlook 2019 (sv)"},
+		{`(?m)^VL:(.*)`, "New Outlook 2019 (fi)"},
+		{`(?m)^Videresend:(.*)`, "New Outlook 2019 (no)"},
+		{`(?m)^İLT:(.*)`, "New Outlook 2019 (tr)"},
+		{`(?m)^Fwd:(.*)`, "Gmail (all locales), Thunderbird (all locales), Missive (en)"},
+	},
+	"Separator": {
+		{`(?m)^>?\s*Begin forwarded message\s?:`, "Apple Mail (en)"},
+		{`(?m)^>?\s*Začátek přeposílané zprávy\s?:`, "Apple Mail (cs)"},
+		{`(?m)^>?\s*Start på videresendt besked\s?:`, "Apple Mail (da)"},
+		{`(?m)^>?\s*Anfang der weitergeleiteten Nachricht\s?:`, "Apple Mail (de)"},
+		{`(?m)^>?\s*Inicio del mensaje reenviado\s?:`, "Apple Mail (es)"},
+		{`(?m)^>?\s*Välitetty viesti alkaa\s?:`, "Apple Mail (fi)"},
+		{`(?m)^>?\s*Début du message réexpédié\s?:`, "Apple Mail (fr)"},
+		{`(?m)^>?\s*Début du message transféré\s?:`, "Apple Mail iOS (fr)"},
+		{`(?m)^>?\s*Započni proslijeđenu poruku\s?:`, "Apple Mail (hr)"},
+		{`(?m)^>?\s*Továbbított levél kezdete\s?:`, "Apple Mail (hu)"},
+		{`(?m)^>?\s*Inizio messaggio inoltrato\s?:`, "Apple Mail (it)"},
+		{`(?m)^>?\s*Begin doorgestuurd bericht\s?:`, "Apple Mail (nl)"},
+		{`(?m)^>?\s*Videresendt melding\s?:`, "Apple Mail (no)"},
+		{`(?m)^>?\s*Początek przekazywanej wiadomości\s?:`, "Apple Mail (pl)"},
+		{`(?m)^>?\s*Início da mensagem reencaminhada\s?:`, "Apple Mail (pt)"},
+		{`(?m)^>?\s*Início da mensagem encaminhada\s?:`, "Apple Mail (pt-br)"},
+		{`(?m)^>?\s*Începe mesajul redirecționat\s?:`, "Apple Mail (ro)"},
+		{`(?m)^>?\s*Начало переадресованного сообщения\s?:`, "Apple Mail (ro)"},
+		{`(?m)^>?\s*Začiatok preposlanej správy\s?:`, "Apple Mail (sk)"},
+		{`(?m)^>?\s*Vidarebefordrat mejl\s?:`, "Apple Mail (sv)"},
+		{`(?m)^>?\s*İleti başlangıcı\s?:`, "Apple Mail (tr)"},
+		{`(?m)^>?\s*Початок листа, що пересилається\s?:`, "Apple Mail (uk)"},
+		{`(?m)^\s*-{8,10}\s*Forwarded message\s*-{8,10}\s*`, "Gmail (all locales), Missive (en), HubSpot (en)"},
+		{`(?m)^\s*_{32}\s*$`, "Outlook Live / 365 (all locales)"},
+		{`(?m)^\s?Dne\s?.+\,\s?.+\s*[\[|<].+[\]|>]\s?napsal\(a\)\s?:`, "Outlook 2019 (cz)"},
+		{`(?m)^\s?D.\s?.+\s?skrev\s?\".+\"\s*[\[|<].+[\]|>]\s?:`, "Outlook 2019 (da)"},
+		{`(?m)^\s?Am\s?.+\s?schrieb\s?\".+\"\s*[\[|<].+[\]|>]\s?:`, "Outlook 2019 (de)"},
+		{`(?m)^\s?On\s?.+\,\s?\".+\"\s*[\[|<].+[\]|>]\s?wrote\s?:`, "Outlook 2019 (en)"},
+		{`(?m)^\s?El\s?.+\,\s?\".+\"\s*[\[|<].+[\]|>]\s?escribió\s?:`, "Outlook 2019 (es)"},
+		{`(?m)^\s?Le\s?.+\,\s?«.+»\s*[\[|<].+[\]|>]\s?a écrit\s?:`, "Outlook 2019 (fr)"},
+		{`(?m)^\s?.+\s*[\[|<].+[\]|>]\s?kirjoitti\s?.+\s?:`, "Outlook 2019 (fi)"},
+		{`(?m)^\s?.+\s?időpontban\s?.+\s*[\[|<|(].+[\]|>|)]\s?ezt írta\s?:`, "Outlook 2019 (hu)"},
+		{`(?m)^\s?Il giorno\s?.+\s?\".+\"\s*[\[|<].+[\]|>]\s?ha scritto\s?:`, "Outlook 2019 (it)"},
+		{`(?m)^\s?Op\s?.+\s?heeft\s?.+\s*[\[|<].+[\]|>]\s?geschreven\s?:`, "Outlook 2019 (nl)"},
+		{`(?m)^\s?.+\s*[\[|<].+[\]|>]\s?skrev følgende den\s?.+\s?:`, "Outlook 2019 (no)"},
+		{`(?m)^\s?Dnia\s?.+\s?„.+”\s*[\[|<].+[\]|>]\s?napisał\s?:`, "Outlook 2019 (pl)"},
+		{`(?m)^\s?Em\s?.+\,\s?\".+\"\s*[\[|<].+[\]|>]\s?escreveu\s?:`, "Outlook 2019 (pt)"},
+		{`(?m)^\s?.+\s?пользователь\s?\".+\"\s*[\[|<].+[\]|>]\s?написал\s?:`, "Outlook 2019 (ru)"},
+		{`(?m)^\s?.+\s?používateľ\s?.+\s*\([\[|<].+[\]|>]\)\s?napísal\s?:`, "Outlook 2019 (sk)"},
+		{`(?m)^\s?Den\s?.+\s?skrev\s?\".+\"\s*[\[|<].+[\]|>]\s?följande\s?:`, "Outlook 2019 (sv)"},
+		{`(?m)^\s?\".+\"\s*[\[|<].+[\]|>]\,\s?.+\s?tarihinde şunu yazdı\s?:`, "Outlook 2019 (tr)"},
+		{`(?m)^\s*-{5,8} Přeposlaná zpráva -{5,8}\s*`, "Yahoo Mail (cs), Thunderbird (cs)"},
+		{`(?m)^\s*-{5,8} Videresendt meddelelse -{5,8}\s*`, "Yahoo Mail (da), Thunderbird (da)"},
+		{`(?m)^\s*-{5,10} Weitergeleitete Nachricht -{5,10}\s*`, "Yahoo Mail (de), Thunderbird (de), HubSpot (de)"},
+		{`(?m)^\s*-{5,8} Forwarded Message -{5,8}\s*`, "Yahoo Mail (en), Thunderbird (en)"},
+		{`(?m)^\s*-{5,10} Mensaje reenviado -{5,10}\s*`, "Yahoo Mail (es), Thunderbird (es), HubSpot (es)"},
+		{`(?m)^\s*-{5,10} Edelleenlähetetty viesti -{5,10}\s*`, "Yahoo Mail (fi), HubSpot (fi)"},
+		{`(?m)^\s*-{5} Message transmis -{5}\s*`, "Yahoo Mail (fr)"},
+		{`(?m)^\s*-{5,8} Továbbított üzenet -{5,8}\s*`, "Yahoo Mail (hu), Thunderbird (hu)"},
+		{`(?m)^\s*-{5,10} Messaggio inoltrato -{5,10}\s*`, "Yahoo Mail (it), HubSpot (it)"},
+		{`(?m)^\s*-{5,10} Doorgestuurd bericht -{5,10}\s*`, "Yahoo Mail (nl), Thunderbird (nl), HubSpot (nl)"},
+		{`(?m)^\s*-{5,8} Videresendt melding -{5,8}\s*`, "Yahoo Mail (no), Thunderbird (no)"},
+		{`(?m)^\s*-{5} Przekazana wiadomość -{5}\s*`, "Yahoo Mail (pl)"},
+		{`(?m)^\s*-{5,8} Mensagem reencaminhada -{5,8}\s*`, "Yahoo Mail (pt), Thunderbird (pt)"},
+		{`(?m)^\s*-{5,10} Mensagem encaminhada -{5,10}\s*`, "Yahoo Mail (pt-br), Thunderbird (pt-br), HubSpot (pt-br)"},
+		{`(?m)^\s*-{5,8} Mesaj redirecționat -{5,8}\s*`, "Yahoo Mail (ro)"},
+		{`(?m)^\s*-{5} Пересылаемое сообщение -{5}\s*`, "Yahoo Mail (ru)"},
+		{`(?m)^\s*-{5} Preposlaná správa -{5}\s*`, "Yahoo Mail (sk)"},
+		{`(?m)^\s*-{5,10} Vidarebefordrat meddelande -{5,10}\s*`, "Yahoo Mail (sv), Thunderbird (sv), HubSpot (sv)"},
+		{`(?m)^\s*-{5} İletilmiş Mesaj -{5}\s*`, "Yahoo Mail (tr)"},
+		{`(?m)^\s*-{5} Перенаправлене повідомлення -{5}\s*`, "Yahoo Mail (uk)"},
+		{`(?m)^\s*-{8} Välitetty viesti \/ Fwd.Msg -{8}\s*`, "Thunderbird (fi)"},
+		{`(?m)^\s*-{8,10} Message transféré -{8,10}\s*`, "Thunderbird (fr), HubSpot (fr)"},
+		{`(?m)^\s*-{8} Proslijeđena poruka -{8}\s*`, "Thunderbird (hr)"},
+		{`(?m)^\s*-{8} Messaggio Inoltrato -{8}\s*`, "Thunderbird (it)"},
+		{`(?m)^\s*-{3} Treść przekazanej wiadomości -{3}\s*`, "Thunderbird (pl)"},
+		{`(?m)^\s*-{8} Перенаправленное сообщение -{8}\s*`, "Thunderbird (ru)"},
+		{`(?m)^\s*-{8} Preposlaná správa --- Forwarded Message -{8}\s*`, "Thunderbird (sk)"},
+		{`(?m)^\s*-{8} İletilen İleti -{8}\s*`, "Thunderbird (tr)"},
+		{`(?m)^\s*-{8} Переслане повідомлення -{8}\s*`, "Thunderbird (uk)"},
+		{`(?m)^\s*-{9,10} メッセージを転送 -{9,10}\s*`, "HubSpot (ja)"},
+		{`(?m)^\s*-{9,10} Wiadomość przesłana dalej -{9,10}\s*`, "HubSpot (pl)"},
+		{`(?m)^>?\s*-{10} Original Message -{10}\s*`, "IONOS by 1 & 1 (en)"},
+	},
+	"SeparatorWithInformation": {
+		{`(?m)^\s?Dne\s?(?P<date>.+)\,\s?(?P<from_name>.+)\s*[\[|<](?P<from_address>.+)[\]|>]\s?napsal\(a\)\s?:`, "Outlook 2019 (cz)"},
+		{`(?m)^\s?D.\s?(?P<date>.+)\s?skrev\s?\"(?P<from_name>.+)\"\s*[\[|<](?P<from_address>.+)[\]|>]\s?:`, "Outlook 2019 (da)"},
+		{`(?m)^\s?Am\s?(?P<date>.+)\s?schrieb\s?\"(?P<from_name>.+)\"\s*[\[|<](?P<from_address>.+)[\]|>]\s?:`, "Outlook 2019 (de)"},
+		{`(?m)^\s?On\s?(?P<date>.+)\,\s?\"(?P<from_name>.+)\"\s*[\[|<](?P<from_address>.+)[\]|>]\s?wrote\s?:`, "Outlook 2019 (en)"},
+		{`(?m)^\s?El\s?(?P<date>.+)\,\s?\"(?P<from_name>.+)\"\s*[\[|<](?P<from_address>.+)[\]|>]\s?escribió\s?:`, "Outlook 2019 (es)"},
+		{`(?m)^\s?Le\s?(?P<date>.+)\,\s?«(?P<from_name>.+)»\s*[\[|<](?P<from_address>.+)[\]|>]\s?a écrit\s?:`, "Outlook 2019 (fr)"},
+		{`(?m)^\s?(?P<from_name>.+)\s*[\[|<](?P<from_address>.+)[\]|>]\s?kirjoitti\s?(?P<date>.+)\s?:`, "Outlook 2019 (fi)"},
+		{`(?m)^\s?(?P<date>.+)\s?időpontban\s?(?P<from_name>.+)\s*[\[|<|(](?P<from_address>.+)[\]|>|)]\s?ezt írta\s?:`, "Outlook 2019 (hu)"},
+		{`(?m)^\s?Il giorno\s?(?P<date>.+)\s?\"(?P<from_name>.+)\"\s*[\[|<](?P<from_address>.+)[\]|>]\s?ha scritto\s?:`, "Outlook 2019 (it)"},
+		{`(?m)^\s?Op\s?(?P<date>.+)\s?heeft\s?(?P<from_name>.+)\s*[\[|<](?P<from_address>.+)[\]|>]\s?geschreven\s?:`, "Outlook 2019 (nl)"},
+		{`(?m)^\s?(?P<from_name>.+)\s*[\[|<](?P<from_address>.+)[\]|>]\s?skrev følgende den\s?(?P<date>.+)\s?:`, "Outlook 2019 (no)"},
+		{`(?m)^\s?Dnia\s?(?P<date>.+)\s?„(?P<from_name>.+)”\s*[\[|<](?P<from_address>.+)[\]|>]\s?napisał\s?:`, "Outlook 2019 (pl)"},
+		{`(?m)^\s?Em\s?(?P<date>.+)\,\s?\"(?P<from_name>.+)\"\s*[\[|<](?P<from_address>.+)[\]|>]\s?escreveu\s?:`, "Outlook 2019 (pt)"},
+		{`(?m)^\s?(?P<date>.+)\s?пользователь\s?\"(?P<from_name>.+)\"\s*[\[|<](?P<from_address>.+)[\]|>]\s?написал\s?:`, "Outlook 2019 (ru)"},
+		{`(?m)^\s?(?P<date>.+)\s?používateľ\s?(?P<from_name>.+)\s*\([\[|<](?P<from_address>.+)[\]|>]\)\s?napísal\s?:`, "Outlook 2019 (sk)"},
+		{`(?m)^\s?Den\s?(?P<date>.+)\s?skrev\s?\"(?P<from_name>.+)\"\s*[\[|<](?P<from_address>.+)[\]|>]\s?följande\s?:`, "Outlook 2019 (sv)"},
+		{`(?m)^\s?\"(?P<from_name>.+)\"\s*[\[|<](?P<from_address>.+)[\]|>]\,\s?(?P<date>.+)\s?tarihinde şunu yazdı\s?:`, "Outlook 2019 (tr)"},
+	},
+	"OriginalSubject": {
+		{`(?im)^\*?Subject\s?:\*?(.+)`, "Apple Mail (en), Gmail (all locales), Outlook Live / 365 (all locales), New Outlook 2019 (en), Thunderbird (da, en), Missive (en), HubSpot (en)"},
+		{`(?im)^Předmět\s?:(.+)`, "Apple Mail (cs), New Outlook 2019 (cs), Thunderbird (cs)"},
+		{`(?im)^Emne\s?:(.+)`, "Apple Mail (da, no), New Outlook 2019 (da), Thunderbird (no)"},
+		{`(?im)^Betreff\s?:(.+)`, "Apple Mail (de), New Outlook 2019 (de), Thunderbird (de), HubSpot (de)"},
+		{`(?im)^Asunto\s?:(.+)`, "Apple Mail (es), New Outlook 2019 (es), Thunderbird (es), HubSpot (es)"},
+		{`(?im)^Aihe\s?:(.+)`, "Apple Mail (fi), New Outlook 2019 (fi), Thunderbird (fi), HubSpot (fi)"},
+		{`(?im)^Objet\s?:(.+)`, "Apple Mail (fr), New Outlook 2019 (fr), HubSpot (fr)"},
+		{`(?im)^Predmet\s?:(.+)`, "Apple Mail (hr, sk), New Outlook 2019 (sk), Thunderbird (sk)"},
+		{`(?im)^Tárgy\s?:(.+)`, "Apple Mail (hu), New Outlook 2019 (hu), Thunderbird (hu)"},
+		{`(?im)^Oggetto\s?:(.+)`, "Apple Mail (it), New Outlook 2019 (it), Thunderbird (it), HubSpot (it)"},
+		{`(?im)^Onderwerp\s?:(.+)`, "Apple Mail (nl), New Outlook 2019 (nl), Thunderbird (nl), HubSpot (nl)"},
+		{`(?im)^Temat\s?:(.+)`, "Apple Mail (pl), New Outlook 2019 (pl), Thunderbird (pl), HubSpot (pl)"},
+		{`(?im)^Assunto\s?:(.+)`, "Apple Mail (pt, pt-br), New Outlook 2019 (pt, pt-br), Thunderbird (pt, pt-br), HubSpot (pt-br)"},
+		{`(?im)^Subiectul\s?:(.+)`, "Apple Mail (ro), Thunderbird (ro)"},
+		{`(?im)^Тема\s?:(.+)`, "Apple Mail (ru, uk), New Outlook 2019 (ru), Thunderbird (ru, uk)"},
+		{`(?im)^Ämne\s?:(.+)`, "Apple Mail (sv), New Outlook 2019 (sv), Thunderbird (sv), HubSpot (sv)"},
+		{`(?im)^Konu\s?:(.+)`, "Apple Mail (tr), Thunderbird (tr)"},
+		{`(?im)^Sujet\s?:(.+)`, "Thunderbird (fr)"},
+		{`(?im)^Naslov\s?:(.+)`, "Thunderbird (hr)"},
+		{`(?im)^件名：(.+)`, "HubSpot (ja)"},
+	},
+	"OriginalSubjectLax": {
+		{`(?i)Subject\s?:(.+)`, "Yahoo Mail (en)"},
+		{`(?i)Emne\s?:(.+)`, "Yahoo Mail (da, no)"},
+		{`(?i)Předmět\s?:(.+)`, "Yahoo Mail (cs)"},
+		{`(?i)Betreff\s?:(.+)`, "Yahoo Mail (de)"},
+		{`(?i)Asunto\s?:(.+)`, "Yahoo Mail (es)"},
+		{`(?i)Aihe\s?:(.+)`, "Yahoo Mail (fi)"},
+		{`(?i)Objet\s?:(.+)`, "Yahoo Mail (fr)"},
+		{`(?i)Tárgy\s?:(.+)`, "Yahoo Mail (hu)"},
+		{`(?i)Oggetto\s?:(.+)`, "Yahoo Mail (it)"},
+		{`(?i)Onderwerp\s?:(.+)`, "Yahoo Mail (nl)"},
+		{`(?i)Assunto\s?:?(.+)`, "Yahoo Mail (pt, pt-br)"},
+		{`(?i)Temat\s?:(.+)`, "Yahoo Mail (pl)"},
+		{`(?i)Subiect\s?:(.+)`, "Yahoo Mail (ro)"},
+		{`(?i)Тема\s?:(.+)`, "Yahoo Mail (ru, uk)"},
+		{`(?i)Predmet\s?:(.+)`, "Yahoo Mail (sk)"},
+		{`(?i)Ämne\s?:(.+)`, "Yahoo Mail (sv)"},
+		{`(?i)Konu\s?:(.+)`, "Yahoo Mail (tr)"},
+	},
+	"OriginalFrom": {
+		{`(?m)^(\*?\s*From\s?:\*?(.+))$`, "Apple Mail (en), Outlook Live / 365 (all locales), New Outlook 2019 (en), Thunderbird (da, en), Missive (en), HubSpot (en)"},
+		{`(?m)^(\s*Od\s?:(.+))$`, "Apple Mail (cs, pl, sk), Gmail (cs, pl, sk), New Outlook 2019 (cs, pl, sk), Thunderbird (cs, sk), HubSpot (pl)"},
+		{`(?m)^(\s*Fra\s?:(.+))$`, "Apple Mail (da, no), Gmail (da, no), New Outlook 2019 (da), Thunderbird (no)"},
+		{`(?m)^(\s*Von\s?:(.+))$`, "Apple Mail (de), Gmail (de), New Outlook 2019 (de), Thunderbird (de), HubSpot (de)"},
+		{`(?m)^(\s*De\s?:(.+))$`, "Apple Mail (es, fr, pt, pt-br), Gmail (es, fr, pt, pt-br), New Outlook 2019 (es, fr, pt, pt-br), Thunderbird (fr, pt, pt-br), HubSpot (es, fr, pt-br)"},
+		{`(?m)^(\s*Lähettäjä\s?:(.+))$`, "Apple Mail (fi), Gmail (fi), New Outlook 2019 (fi), Thunderbird (fi), HubSpot (fi)"},
+		{`(?m)^(\s*Šalje\s?:(.+))$`, "Apple Mail (hr), Gmail (hr), Thunderbird (hr)"},
+		{`(?m)^(\s*Feladó\s?:(.+))$`, "Apple Mail (hu), Gmail (hu), New Outlook 2019 (fr), Thunderbird (hu)"},
+		{`(?m)^(\s*Da\s?:(.+))$`, "Apple Mail (it), Gmail (it), New Outlook 2019 (it), HubSpot (it)"},
+		{`(?m)^(\s*Van\s?:(.+))$`, "Apple Mail (nl), Gmail (nl), New Outlook 2019 (nl), Thunderbird (nl), HubSpot (nl)"},
+		{`(?m)^(\s*Expeditorul\s?:(.+))$`, "Apple Mail (ro)"},
+		{`(?m)^(\s*Отправитель\s?:(.+))$`, "Apple Mail (ru)"},
+		{`(?m)^(\s*Från\s?:(.+))$`, "Apple Mail (sv), Gmail (sv), New Outlook 2019 (sv), Thunderbird (sv), HubSpot (sv)"},
+		{`(?m)^(\s*Kimden\s?:(.+))$`, "Apple Mail (tr), Thunderbird (tr)"},
+		{`(?m)^(\s*Від кого\s?:(.+))$`, "Apple Mail (uk)"},
+		{`(?m)^(\s*Saatja\s?:(.+))$`, "Gmail (et)"},
+		{`(?m)^(\s*De la\s?:(.+))$`, "Gmail (ro)"},
+		{`(?m)^(\s*Gönderen\s?:(.+))$`, "Gmail (tr)"},
+		{`(?m)^(\s*От\s?:(.+))$`, "Gmail (ru), New Outlook 2019 (ru), Thunderbird (ru)"},
+		{`(?m)^(\s*Від\s?:(.+))$`, "Gmail (uk), Thunderbird (uk)"},
+		{`(?m)^(\s*Mittente\s?:(.+))$`, "Thunderbird (it)"},
+		{`(?m)^(\s*Nadawca\s?:(.+))$`, "Thunderbird (pl)"},
+		{`(?m)^(\s*de la\s?:(.+))$`, "Thunderbird (ro)"},
+		{`(?m)^(\s*送信元：(.+))$`, "HubSpot (ja)"},
+	},
+	"OriginalFromLax": {
+		{`(\s*From\s?:(.+?)\s?\n?\s*[\[|<](.+?)[\]|>])`, "Yahoo Mail (en)"},
+		{`(\s*Od\s?:(.+?)\s?\n?\s*[\[|<](.+?)[\]|>])`, "Yahoo Mail (cs, pl, sk)"},
+		{`(\s*Fra\s?:(.+?)\s?\n?\s*[\[|<](.+?)[\]|>])`, "Yahoo Mail (da, no)"},
+		{`(\s*Von\s?:(.+?)\s?\n?\s*[\[|<](.+?)[\]|>])`, "Yahoo Mail (de)"},
+		{`(\s*De\s?:(.+?)\s?\n?\s*[\[|<](.+?)[\]|>])`, "Yahoo Mail (es, fr, pt, pt-br)"},
+		{`(\s*Lähettäjä\s?:(.+?)\s?\n?\s*[\[|<](.+?)[\]|>])`, "Yahoo Mail (fi)"},
+		{`(\s*Feladó\s?:(.+?)\s?\n?\s*[\[|<](.+?)[\]|>])`, "Yahoo Mail (hu)"},
+		{`(\s*Da\s?:(.+?)\s?\n?\s*[\[|<](.+?)[\]|>])`, "Yahoo Mail (it)"},
+		{`(\s*Van\s?:(.+?)\s?\n?\s*[\[|<](.+?)[\]|>])`, "Yahoo Mail (nl)"},
+		{`(\s*De la\s?:(.+?)\s?\n?\s*[\[|<](.+?)[\]|>])`, "Yahoo Mail (ro)"},
+		{`(\s*От\s?:(.+?)\s?\n?\s*[\[|<](.+?)[\]|>])`, "Yahoo Mail (ru)"},
+		{`(\s*Från\s?:(.+?)\s?\n?\s*[\[|<](.+?)[\]|>])`, "Yahoo Mail (sv)"},
+		{`(\s*Kimden\s?:(.+?)\s?\n?\s*[\[|<](.+?)[\]|>])`, "Yahoo Mail (tr)"},
+		{`(\s*Від\s?:(.+?)\s?\n?\s*[\[|<](.+?)[\]|>])`, "Yahoo Mail (uk)"},
+	},
+	"OriginalTo": {
+		{`(?m)^\*?\s*To\s?:\*?(.+)$`, "Apple Mail (en), Gmail (all locales), Outlook Live / 365 (all locales), Thunderbird (da, en), Missive (en), HubSpot (en)"},
+		{`(?m)^\s*Komu\s?:(.+)$`, "Apple Mail (cs), New Outlook 2019 (cs, sk), Thunderbird (cs)"},
+		{`(?m)^\s*Til\s?:(.+)$`, "Apple Mail (da, no), New Outlook 2019 (da), Thunderbird (no)"},
+		{`(?m)^\s*An\s?:(.+)$`, "Apple Mail (de), New Outlook 2019 (de), Thunderbird (de), HubSpot (de)"},
+		{`(?m)^\s*Para\s?:(.+)$`, "Apple Mail (es, pt, pt-br), New Outlook 2019 (es, pt, pt-br), Thunderbird (es, pt, pt-br), HubSpot (pt-br)"},
+		{`(?m)^\s*Vastaanottaja\s?:(.+)$`, "Apple Mail (fi), New Outlook 2019 (fi), Thunderbird (fi), HubSpot (fi)"},
+		{`(?m)^\s*À\s?:(.+)$`, "Apple Mail (fr), New Outlook 2019 (fr), HubSpot (fr)"},
+		{`(?m)^\s*Prima\s?:(.+)$`, "Apple Mail (hr), Thunderbird (hr)"},
+		{`(?m)^\s*Címzett\s?:(.+)$`, "Apple Mail (hu), New Outlook 2019 (hu), Thunderbird (hu)"},
+		{`(?m)^\s*A\s?:(.+)$`, "Apple Mail (it), New Outlook 2019 (it), Thunderbird (it), HubSpot (es, it)"},
+		{`(?m)^\s*Aan\s?:(.+)$`, "Apple Mail (nl), New Outlook 2019 (nl), Thunderbird (nl), HubSpot (nl)"},
+		{`(?m)^\s*Do\s?:(.+)$`, "Apple Mail (pl), New Outlook 2019 (pl), HubSpot (pl)"},
+		{`(?m)^\s*Destinatarul\s?:(.+)$`, "Apple Mail (ro)"},
+		{`(?m)^\s*Кому\s?:(.+)$`, "Apple Mail (ru, uk), New Outlook 2019 (ru), Thunderbird (ru, uk)"},
+		{`(?m)^\s*Pre\s?:(.+)$`, "Apple Mail (sk), Thunderbird (sk)"},
+		{`(?m)^\s*Till\s?:(.+)$`, "Apple Mail (sv), New Outlook 2019 (sv), Thunderbird (sv)"},
+		{`(?m)^\s*Kime\s?:(.+)$`, "Apple Mail (tr), Thunderbird (tr)"},
+		{`(?m)^\s*Pour\s?:(.+)$`, "Thunderbird (fr)"},
+		{`(?m)^\s*Adresat\s?:(.+)$`, "Thunderbird (pl)"},
+		{`(?m)^\s*送信先：(.+)$`, "HubSpot (ja)"},
+	},
+	"OriginalToLax": {
+		{`(?m)\s*To\s?:(.+)$`, "Yahook Mail (en)"},
+		{`(?m)\s*Komu\s?:(.+)$`, "Yahook Mail (cs, sk)"},
+		{`(?m)\s*Til\s?:(.+)$`, "Yahook Mail (da, no, sv)"},
+		{`(?m)\s*An\s?:(.+)$`, "Yahook Mail (de)"},
+		{`(?m)\s*Para\s?:(.+)$`, "Yahook Mail (es, pt, pt-br)"},
+		{`(?m)\s*Vastaanottaja\s?:(.+)$`, "Yahook Mail (fi)"},
+		{`(?m)\s*À\s?:(.+)$`, "Yahook Mail (fr)"},
+		{`(?m)\s*Címzett\s?:(.+)$`, "Yahook Mail (hu)"},
+		{`(?m)\s*A\s?:(.+)$`, "Yahook Mail (it)"},
+		{`(?m)\s*Aan\s?:(.+)$`, "Yahook Mail (nl)"},
+		{`(?m)\s*Do\s?:(.+)$`, "Yahook Mail (pl)"},
+		{`(?m)\s*Către\s?:(.+)$`, "Yahook Mail (ro), Thunderbird (ro)"},
+		{`(?m)\s*Кому\s?:(.+)$`, "Yahook Mail (ru, uk)"},
+		{`(?m)\s*Till\s?:(.+)$`, "Yahook Mail (sv)"},
+		{`(?m)\s*Kime\s?:(.+)$`, "Yahook Mail (tr)"},
+	},
+	"OriginalReplyTo": {
+		{`(?m)^\s*Reply-To\s?:(.+)$`, "Apple Mail (en)"},
+		{`(?m)^\s*Odgovori na\s?:(.+)$`, "Apple Mail (hr)"},
+		{`(?m)^\s*Odpověď na\s?:(.+)$`, "Apple Mail (cs)"},
+		{`(?m)^\s*Svar til\s?:(.+)$`, "Apple Mail (da)"},
+		{`(?m)^\s*Antwoord aan\s?:(.+)$`, "Apple Mail (nl)"},
+		{`(?m)^\s*Vastaus\s?:(.+)$`, "Apple Mail (fi)"},
+		{`(?m)^\s*Répondre à\s?:(.+)$`, "Apple Mail (fr)"},
+		{`(?m)^\s*Antwort an\s?:(.+)$`, "Apple Mail (de)"},
+		{`(?m)^\s*Válaszcím\s?:(.+)$`, "Apple Mail (hu)"},
+		{`(?m)^\s*Rispondi a\s?:(.+)$`, "Apple Mail (it)"},
+		{`(?m)^\s*Svar til\s?:(.+)$`, "Apple Mail (no)"},
+		{`(?m)^\s*Odpowiedź-do\s?:(.+)$`, "Apple Mail (pl)"},
+		{`(?m)^\s*Responder A\s?:(.+)$`, "Apple Mail (pt)"},
+		{`(?m)^\s*Responder a\s?:(.+)$`, "Apple Mail (pt-br, es)"},
+		{`(?m)^\s*Răspuns către\s?:(.+)$`, "Apple Mail (ro)"},
+		{`(?m)^\s*Ответ-Кому\s?:(.+)$`, "Apple Mail (ru)"},
+		{`(?m)^\s*Odpovedať-Pre\s?:(.+)$`, "Apple Mail (sk)"},
+		{`(?m)^\s*Svara till\s?:(.+)$`, "Apple Mail (sv)"},
+		{`(?m)^\s*Yanıt Adresi\s?:(.+)$`, "Apple Mail (tr)"},
+		{`(?m)^\s*Кому відповісти\s?:(.+)$`, "Apple Mail (uk)"},
+	},
+	"OriginalCC": {
+		{`(?m)^\*?\s*Cc\s?:\*?(.+)$`, "Apple Mail (en, da, es, fr, hr, it, pt, pt-br, ro, sk), Gmail (all locales), Outlook Live / 365 (all locales), New Outlook 2019 (da, de, en, fr, it, pt-br), Missive (en), HubSpot (de, en, es, it, nl, pt-br)"},
+		{`(?m)^\s*CC\s?:(.+)$`, "New Outlook 2019 (es, nl, pt), Thunderbird (da, en, es, fi, hr, hu, it, nl, no, pt, pt-br, ro, tr, uk)"},
+		{`(?m)^\s*Kopie\s?:(.+)$`, "Apple Mail (cs, de, nl), New Outlook 2019 (cs), Thunderbird (cs)"},
+		{`(?m)^\s*Kopio\s?:(.+)$`, "Apple Mail (fi), New Outlook 2019 (fi), HubSpot (fi)"},
+		{`(?m)^\s*Másolat\s?:(.+)$`, "Apple Mail (hu)"},
+		{`(?m)^\s*Kopi\s?:(.+)$`, "Apple Mail (no)"},
+		{`(?m)^\s*Dw\s?:(.+)$`, "Apple Mail (pl)"},
+		{`(?m)^\s*Копия\s?:(.+)$`, "Apple Mail (ru), New Outlook 2019 (ru), Thunderbird (ru)"},
+		{`(?m)^\s*Kopia\s?:(.+)$`, "Apple Mail (sv), New Outlook 2019 (sv), Thunderbird (pl, sv), HubSpot (sv)"},
+		{`(?m)^\s*Bilgi\s?:(.+)$`, "Apple Mail (tr)"},
+		{`(?m)^\s*Копія\s?:(.+)$`, "Apple Mail (uk),"},
+		{`(?m)^\s*Másolatot kap\s?:(.+)$`, "New Outlook 2019 (hu)"},
+		{`(?m)^\s*Kópia\s?:(.+)$`, "New Outlook 2019 (sk), Thunderbird (sk)"},
+		{`(?m)^\s*DW\s?:(.+)$`, "New Outlook 2019 (pl), HubSpot (pl)"},
+		{`(?m)^\s*Kopie \(CC\)\s?:(.+)$`, "Thunderbird (de)"},
+		{`(?m)^\s*Copie à\s?:(.+)$`, "Thunderbird (fr)"},
+		{`(?m)^\s*CC：(.+)$`, "HubSpot (ja)}"},
+	},
+	"OriginalCCLax": {
+		{`(?m)\s*Cc\s?:(.+)$`, "Yahoo Mail (da, en, it, nl, pt, pt-br, ro, tr)"},
+		{`(?m)\s*CC\s?:(.+)$`, "Yahoo Mail (de, es)"},
+		{`(?m)\s*Kopie\s?:(.+)$`, "Yahoo Mail (cs)"},
+		{`(?m)\s*Kopio\s?:(.+)$`, "Yahoo Mail (fi)"},
+		{`(?m)\s*Másolat\s?:(.+)$`, "Yahoo Mail (hu)"},
+		{`(?m)\s*Kopi\s?:(.+)$`, "Yahoo Mail (no)"},
+		{`(?m)\s*Dw\s?(.+)$`, "Yahoo Mail (pl)"},
+		{`(?m)\s*Копия\s?:(.+)$`, "Yahoo Mail (ru)"},
+		{`(?m)\s*Kópia\s?:(.+)$`, "Yahoo Mail (sk)"},
+		{`(?m)\s*Kopia\s?:(.+)$`, "Yahoo Mail (sv)"},
+		{`(?m)\s*Копія\s?:(.+)$`, "Yahoo Mail (uk)"},
+	},
+	"OriginalDate": {
+		{`(?m)^\s*Date\s?:(.+)$`, "Apple Mail (en, fr), Gmail (all locales), New Outlook 2019 (en, fr), Thunderbird (da, en, fr), Missive (en), HubSpot (en, fr)"},
+		{`(?m)^\s*Datum\s?:(.+)$`, "Apple Mail (cs, de, hr, nl, sv), New Outlook 2019 (cs, de, nl, sv), Thunderbird (cs, de, hr, nl, sv), HubSpot (de, nl, sv)"},
+		{`(?m)^\s*Dato\s?:(.+)$`, "Apple Mail (da, no), New Outlook 2019 (da), Thunderbird (no)"},
+		{`(?m)^\s*Envoyé\s?:(.+)$`, "New Outlook 2019 (fr)"},
+		{`(?m)^\s*Fecha\s?:(.+)$`, "Apple Mail (es), New Outlook 2019 (es), Thunderbird (es), HubSpot (es)"},
+		{`(?m)^\s*Päivämäärä\s?:(.+)$`, "Apple Mail (fi), New Outlook 2019 (fi), HubSpot (fi)"},
+		{`(?m)^\s*Dátum\s?:(.+)$`, "Apple Mail (hu, sk), New Outlook 2019 (sk), Thunderbird (hu, sk)"},
+		{`(?m)^\s*Data\s?:(.+)$`, "Apple Mail (it, pl, pt, pt-br), New Outlook 2019 (it, pl, pt, pt-br), Thunderbird (it, pl, pt, pt-br), HubSpot (it, pl, pt-br)"},
+		{`(?m)^\s*Dată\s?:(.+)$`, "Apple Mail (ro), Thunderbird (ro)"},
+		{`(?m)^\s*Дата\s?:(.+)$`, "Apple Mail (ru, uk), New Outlook 2019 (ru), Thunderbird (ru, uk)"},
+		{`(?m)^\s*Tarih\s?:(.+)$`, "Apple Mail (tr), Thunderbird (tr)"},
+		{`(?m)^\*?\s*Sent\s?:\*?(.+)$`, "Outlook Live / 365 (all locales)"},
+		{`(?m)^\s*Päiväys\s?:(.+)$`, "Thunderbird (fi)"},
+		{`(?m)^\s*日付：(.+)$`, "HubSpot (ja)"},
+	},
+	"OriginalDateLax": {
+		{`(?m)\s*Datum\s?:(.+)$`, "Yahoo Mail (cs)"},
+		{`(?m)\s*Sendt\s?:(.+)$`, "Yahoo Mail (da, no)"},
+		{`(?m)\s*Gesendet\s?:(.+)$`, "Yahoo Mail (de)"},
+		{`(?m)\s*Sent\s?:(.+)$`, "Yahoo Mail (en)"},
+		{`(?m)\s*Enviado\s?:(.+)$`, "Yahoo Mail (es, pt, pt-br)"},
+		{`(?m)\s*Envoyé\s?:(.+)$`, "Yahoo Mail (fr)"},
+		{`(?m)\s*Lähetetty\s?:(.+)$`, "Yahoo Mail (fi)"},
+		{`(?m)\s*Elküldve\s?:(.+)$`, "Yahoo Mail (hu)"},
+		{`(?m)\s*Inviato\s?:(.+)$`, "Yahoo Mail (it)"},
+		{`(?m)\s*Verzonden\s?:(.+)$`, "Yahoo Mail (it)"},
+		{`(?m)\s*Wysłano\s?:(.+)$`, "Yahoo Mail (pl)"},
+		{`(?m)\s*Trimis\s?:(.+)$`, "Yahoo Mail (ro)"},
+		{`(?m)\s*Отправлено\s?:(.+)$`, "Yahoo Mail (ru)"},
+		{`(?m)\s*Odoslané\s?:(.+)$`, "Yahoo Mail (sk)"},
+		{`(?m)\s*Skickat\s?:(.+)$`, "Yahoo Mail (sv)"},
+		{`(?m)\s*Gönderilen\s?:(.+)$`, "Yahoo Mail (tr)"},
+		{`(?m)\s*Відправлено\s?:(.+)$`, "Yahoo Mail (uk)"},
+	},
+	"Mailbox": {
+		{`^\s?\n?\s*<.+?<mailto\:(.+?)>>`, ""},
+		{`^(.+?)\s?\n?\s*<.+?<mailto\:(.+?)>>`, ""},
+		{`^(.+?)\s?\n?\s*[\[|<]mailto\:(.+?)[\]|>]`, ""},
+		{`^\'(.+?)\'\s?\n?\s*[\[|<](.+?)[\]|>]`, ""},
+		{`^\"\'(.+?)\'\"\s?\n?\s*[\[|<](.+?)[\]|>]`, ""},
+		{`^\"(.+?)\"\s?\n?\s*[\[|<](.+?)[\]|>]`, ""},
+		{`^([^,;]+?)\s?\n?\s*[\[|<](.+?)[\]|>]`, ""},
+		{`^(.?)\s?\n?\s*[\[|<](.+?)[\]|>]`, ""},
+		{`^([^\s@]+@[^\s@]+\.[^\s@,]+)`, ""},
+		{`^([^;].+?)\s?\n?\s*[\[|<](.+?)[\]|>]`, ""},
+	},
+	"MailboxAddress": {
+		{`^(([^\s@]+)@([^\s@]+)\.([^\s@]+))$`, ""},
+	},
+}
+
+// The expressions changed by the move to the locale files, whose dots are
+// now escaped
+var _BaselineExprChanges = map[string]string{
+	`(?m)^Redir.:(.*)`: `(?m)^Redir\.:(.*)`,
+	`(?m)^\s*-{8} Välitetty viesti \/ Fwd.Msg -{8}\s*`: `(?m)^\s*-{8} Välitetty viesti / Fwd\.Msg -{8}\s*`,
+}
+
+// The clients changed by the move to the locale files
+var _BaselineSourceChanges = map[string]string{
+	// Typos: "cz", "Yahook Mail", and the locales of other patterns
+	`(?m)^>?\s*Начало переадресованного сообщения\s?:`:                                                      "Apple Mail (ru)",
+	`(?m)^\s?Dne\s?.+\,\s?.+\s*[\[|<].+[\]|>]\s?napsal\(a\)\s?:`:                                            "Outlook 2019 (cs)",
+	`(?m)^\s?Dne\s?(?P<date>.+)\,\s?(?P<from_name>.+)\s*[\[|<](?P<from_address>.+)[\]|>]\s?napsal\(a\)\s?:`: "Outlook 2019 (cs)",
+	`(?m)^(\s*Feladó\s?:(.+))$`:     "Apple Mail (hu), Gmail (hu), New Outlook 2019 (hu), Thunderbird (hu)",
+	`(?m)\s*Verzonden\s?:(.+)$`:     "Yahoo Mail (nl)",
+	`(?m)\s*To\s?:(.+)$`:            "Yahoo Mail (en)",
+	`(?m)\s*Komu\s?:(.+)$`:          "Yahoo Mail (cs, sk)",
+	`(?m)\s*Til\s?:(.+)$`:           "Yahoo Mail (da, no, sv)",
+	`(?m)\s*An\s?:(.+)$`:            "Yahoo Mail (de)",
+	`(?m)\s*Para\s?:(.+)$`:          "Yahoo Mail (es, pt-br, pt)",
+	`(?m)\s*Vastaanottaja\s?:(.+)$`: "Yahoo Mail (fi)",
+	`(?m)\s*À\s?:(.+)$`:             "Yahoo Mail (fr)",
+	`(?m)\s*Címzett\s?:(.+)$`:       "Yahoo Mail (hu)",
+	`(?m)\s*A\s?:(.+)$`:             "Yahoo Mail (it)",
+	`(?m)\s*Aan\s?:(.+)$`:           "Yahoo Mail (nl)",
+	`(?m)\s*Do\s?:(.+)$`:            "Yahoo Mail (pl)",
+	`(?m)\s*Către\s?:(.+)$`:         "Yahoo Mail (ro), Thunderbird (ro)",
+	`(?m)\s*Кому\s?:(.+)$`:          "Yahoo Mail (ru, uk)",
+	`(?m)\s*Till\s?:(.+)$`:          "Yahoo Mail (sv)",
+	`(?m)\s*Kime\s?:(.+)$`:          "Yahoo Mail (tr)",
+
+	// Clients the locale files list for every header of the locales they
+	// write, such as Gmail and IONOS for "From"
+	`(?m)^\s*-{5,8} Mesaj redirecționat -{5,8}\s*`: "Yahoo Mail (ro), Thunderbird (ro)",
+	`(?im)^\*?Subject\s?:\*?(.+)`:                  "Gmail (all locales), Outlook Live / 365 (all locales), Thunderbird (da, en), Apple Mail (en), New Outlook 2019 (en), Missive (en), HubSpot (en), IONOS by 1 & 1 (en)",
+	`(?im)^Emne\s?:(.+)`:                           "Apple Mail (da, no), New Outlook 2019 (da, no), Thunderbird (no)",
+	`(?im)^Konu\s?:(.+)`:                           "Apple Mail (tr), Thunderbird (tr), New Outlook 2019 (tr)",
+	`(?i)Subiect\s?:(.+)`:                          "Yahoo Mail (ro), Thunderbird (ro)",
+	`(?m)^(\*?\s*From\s?:\*?(.+))$`:                "Outlook Live / 365 (all locales), Thunderbird (da, en), Apple Mail (en), Gmail (en), New Outlook 2019 (en), Missive (en), HubSpot (en), IONOS by 1 & 1 (en)",
+	`(?m)^(\s*Fra\s?:(.+))$`:                       "Apple Mail (da, no), Gmail (da, no), New Outlook 2019 (da, no), Thunderbird (no)",
+	`(?m)^(\s*De\s?:(.+))$`:                        "Apple Mail (es, fr, pt-br, pt), Gmail (es, fr, pt-br, pt), New Outlook 2019 (es, fr, pt-br, pt), Thunderbird (es, fr, pt-br, pt), HubSpot (es, fr, pt-br)",
+	`(?m)^(\s*Kimden\s?:(.+))$`:                    "Apple Mail (tr), Thunderbird (tr), New Outlook 2019 (tr)",
+	`(?m)^\s*Reply-To\s?:(.+)$`:                    "Apple Mail (en), Thunderbird (en)",
+	`(?m)^\s*CC\s?:(.+)$`:                          "Thunderbird (da, en, es, fi, hr, hu, it, nl, no, pt-br, pt, ro, tr, uk), New Outlook 2019 (es, fi, nl, pt), Apple Mail (fi, hu, no, tr, uk), HubSpot (fi)",
+	`(?m)^\s*Date\s?:(.+)$`:                        "Gmail (all locales), Thunderbird (da, en, fr), Apple Mail (en, fr), New Outlook 2019 (en, fr), Missive (en), HubSpot (en, fr), IONOS by 1 & 1 (en)",
+	`(?m)^\s*Dato\s?:(.+)$`:                        "Apple Mail (da, no), New Outlook 2019 (da, no), Thunderbird (no)",
+	`(?m)^\s*Tarih\s?:(.+)$`:                       "Apple Mail (tr), Thunderbird (tr), New Outlook 2019 (tr)",
+}
+
+// _BaselineExpr normalizes an expression, whose named groups the locale
+// files may add or remove
+func _BaselineExpr(expr string) string {
+	return strings.ReplaceAll(_UncaptureGroups(expr), "(?:.+)", ".+")
+}
+
+func _SortedSources(annotation string) []string {
+	sources := []string{}
+
+	for _, source := range _ParseClientSources(annotation) {
+		locales := append([]string{}, source.Locales...)
+		sort.Strings(locales)
+
+		sources = append(sources, source.Client+" ("+strings.Join(locales, ", ")+")")
+	}
+
+	sort.Strings(sources)
+
+	return sources
+}
+
+// The default patterns, loaded from the locale files, are the baseline ones
+// but for the listed changes, in any order as ties between clients are not
+// broken by it
+func TestDefaultPatternsBaseline(t *testing.T) {
+	set := DefaultPatternSet()
+
+	for _, field := range set._Fields() {
+		baseline, ok := _BaselinePatterns[field.Name]
+		if !ok {
+			continue
+		}
+
+		patterns := map[string]Pattern{}
+
+		for _, pattern := range *field.Patterns {
+			patterns[_BaselineExpr(pattern.Expr)] = pattern
+		}
+
+		// Patterns written twice in the baseline are merged
+		merged := []Pattern{}
+
+		for _, pattern := range baseline {
+			merged = _MergePattern(merged, pattern)
+		}
+
+		if len(patterns) != len(merged) {
+			t.Error(field.Name, "unexpected number of patterns", len(patterns), len(merged))
+		}
+
+		for _, expected := range merged {
+			expr := expected.Expr
+			if changed, ok := _BaselineExprChanges[expr]; ok {
+				expr = changed
+			}
+
+			pattern, ok := patterns[_BaselineExpr(expr)]
+			if !ok {
+				t.Error(field.Name, "missing pattern", expected.Expr)
+				continue
+			}
+
+			if field.Name == "Mailbox" || field.Name == "MailboxAddress" {
+				continue
+			}
+
+			source := expected.Source
+			if changed, ok := _BaselineSourceChanges[expected.Expr]; ok {
+				source = changed
+			}
+
+			if !reflect.DeepEqual(_SortedSources(pattern.Source), _SortedSources(source)) {
+				t.Errorf("%s %s: unexpected clients %q, expected %q", field.Name, expected.Expr, pattern.Source, source)
+			}
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package emailforwardparser
+
+import (
+	"strings"
+	"testing"
+)
+
+// The average number of allocations of a Read of the fixtures, documented in
+// the README
+const _ReadAllocationBudget = 250
+
+// _FixtureClient returns the client of a fixture, such as "apple_mail" for
+// "apple_mail_pt_br_body_variant_2"
+func _FixtureClient(name string) string {
+	client := strings.TrimSuffix(name[:strings.Index(name, "_body")], "_pt_br")
+
+	if i := strings.LastIndexByte(client, '_'); i >= 0 && len(client)-i == 3 {
+		client = client[:i]
+	}
+
+	return client
+}
+
+func TestFixtureClient(t *testing.T) {
+	for name, expected := range map[string]string{
+		"apple_mail_pt_br_body_variant_2": "apple_mail",
+		"new_outlook_2019_en_body":        "new_outlook_2019",
+		"outlook_live_body":               "outlook_live",
+		"ionos_one_and_one_en_body":       "ionos_one_and_one",
+	} {
+		if client := _FixtureClient(name); client != expected {
+			t.Errorf("%s: unexpected %s, expected %s", name, client, expected)
+		}
+	}
+}
+
+func TestReadAllocations(t *testing.T) {
+	if testing.Short() {
+		t.Skip("reads every fixture")
+	}
+
+	fixtures := _ReadFixtures(t)
+
+	allocations := testing.AllocsPerRun(1, func() {
+		for _, fixture := range fixtures {
+			Read(fixture.Body, fixture.Subject)
+		}
+	}) / float64(len(fixtures))
+
+	if allocations > _ReadAllocationBudget {
+		t.Errorf("%.0f allocations per read, over the budget of %d", allocations, _ReadAllocationBudget)
+	}
+}
+
+// BenchmarkReadClients reads the fixtures of each client; ns/op and allocs/op
+// are per fixture
+func BenchmarkReadClients(b *testing.B) {
+	clients := []string{}
+	fixtures := map[string][]_Fixture{}
+
+	for _, fixture := range _ReadFixtures(b) {
+		client := _FixtureClient(fixture.Name)
+
+		if _, ok := fixtures[client]; !ok {
+			clients = append(clients, client)
+		}
+
+		fixtures[client] = append(fixtures[client], fixture)
+	}
+
+	for _, client := range clients {
+		clientFixtures := fixtures[client]
+
+		b.Run(client, func(b *testing.B) {
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				fixture := clientFixtures[i%len(clientFixtures)]
+
+				Read(fixture.Body, fixture.Subject)
+			}
+		})
+	}
+}
@@ -0,0 +1,273 @@
+package emailforwardparser
+
+import (
+	"strings"
+
+	regexp "github.com/wasilibs/go-re2"
+)
+
+type _ClientSource struct {
+	Client  string
+	Locales []string // nil for "all locales"
+}
+
+var _ClientSourceEntry = regexp.MustCompile(`\s*([^,(]+?)\s*\(([^)]*)\)`)
+
+// _ParseClientSources parses an annotation such as
+// "Outlook Live / 365 (nl, pt), New Outlook 2019 (cs, en)"
+func _ParseClientSources(annotation string) []_ClientSource {
+	sources := []_ClientSource{}
+
+	for _, match := range _ClientSourceEntry.FindAllStringSubmatch(annotation, -1) {
+		source := _ClientSource{Client: trimString(match[1])}
+
+		if locales := trimString(match[2]); locales != "all locales" {
+			for _, locale := range strings.Split(locales, ",") {
+				source.Locales = append(source.Locales, trimString(locale))
+			}
+		}
+
+		sources = append(sources, source)
+	}
+
+	return sources
+}
+
+func _FormatClientSources(sources []_ClientSource) string {
+	annotations := make([]string, 0, len(sources))
+
+	for _, source := range sources {
+		locales := "all locales"
+
+		if source.Locales != nil {
+			locales = strings.Join(source.Locales, ", ")
+		}
+
+		annotations = append(annotations, source.Client+" ("+locales+")")
+	}
+
+	return strings.Join(annotations, ", ")
+}
+
+func _MergeClientSources(sources []_ClientSource, other []_ClientSource) []_ClientSource {
+	merged := append([]_ClientSource{}, sources...)
+
+	for _, source := range other {
+		found := false
+
+		for i := range merged {
+			if merged[i].Client != source.Client {
+				continue
+			}
+
+			found = true
+
+			if merged[i].Locales == nil || source.Locales == nil {
+				merged[i].Locales = nil
+				break
+			}
+
+			locales := append([]string{}, merged[i].Locales...)
+
+			for _, locale := range source.Locales {
+				if !_ContainsString(locales, locale) {
+					locales = append(locales, locale)
+				}
+			}
+
+			merged[i].Locales = locales
+
+			break
+		}
+
+		if !found {
+			merged = append(merged, source)
+		}
+	}
+
+	return merged
+}
+
+// _DetectClient guesses the client and locale that produced a forward from
+// the patterns that matched it: the separator, the subject prefix, then the
+// header patterns. Each pattern votes for the clients and locales it was
+// written for, the separator counting double as it is the most specific.
+// When the header patterns of a client were written for all locales, the
+// language of the date votes for its locale in their stead, unless no client
+// writes the subject prefix in that locale; ties are broken by the language
+// of the date.
+//
+// Rather than guessing, the client and locale are left empty when the best
+// score is still tied between clients, or when the separator (or, lacking
+// one, the subject prefix) and the header patterns vote for different
+// clients; the locale alone is left empty when tied between locales.
+func (parser *Parser) _DetectClient(patterns []*regexp.Regexp, date string) (string, string) {
+	clients := []string{}
+	locales := []string{}
+
+	for _, pattern := range patterns {
+		for _, source := range parser.patterns.Sources[pattern] {
+			if !_ContainsString(clients, source.Client) {
+				clients = append(clients, source.Client)
+			}
+
+			for _, locale := range source.Locales {
+				if !_ContainsString(locales, locale) {
+					locales = append(locales, locale)
+				}
+			}
+		}
+	}
+
+	if len(locales) == 0 {
+		locales = append(locales, "")
+	}
+
+	words := _DateWords(strings.ToLower(date))
+	dateScores := map[string]int{}
+
+	bestDateScore := 0
+
+	for _, dateLocale := range _DateLocales {
+		dateScores[dateLocale.Name] = dateLocale.score(words)
+
+		if dateScores[dateLocale.Name] > bestDateScore {
+			bestDateScore = dateScores[dateLocale.Name]
+		}
+	}
+
+	// The clients and locales with the best score
+	bestClients, bestLocales := []string{}, []string{}
+	bestScore := 0
+
+	for _, client := range clients {
+		// The separator and subject prefix come before the header patterns
+		headersLocalized := false
+
+		for _, pattern := range patterns[2:] {
+			for _, source := range parser.patterns.Sources[pattern] {
+				if source.Client == client && source.Locales != nil {
+					headersLocalized = true
+				}
+			}
+		}
+
+		for _, locale := range locales {
+			score := 0
+
+			for i, pattern := range patterns {
+				if parser._VotesFor(pattern, client, locale) {
+					if i == 0 {
+						score += 2
+					} else {
+						score++
+					}
+				}
+			}
+
+			if !headersLocalized && bestDateScore > 0 && dateScores[_BaseLocale(locale)] == bestDateScore && parser._WrittenIn(patterns[1], locale) {
+				score++
+			}
+
+			if score == 0 || score < bestScore {
+				continue
+			}
+
+			if score == bestScore && len(bestLocales) > 0 {
+				best := dateScores[_BaseLocale(bestLocales[0])]
+
+				if dateScores[_BaseLocale(locale)] < best {
+					continue
+				}
+
+				if dateScores[_BaseLocale(locale)] == best {
+					bestClients = append(bestClients, client)
+					bestLocales = append(bestLocales, locale)
+
+					continue
+				}
+			}
+
+			bestClients, bestLocales = []string{client}, []string{locale}
+			bestScore = score
+		}
+	}
+
+	if len(bestClients) == 0 {
+		return "", ""
+	}
+
+	client, locale := bestClients[0], bestLocales[0]
+
+	for i := range bestClients {
+		if bestClients[i] != client {
+			return "", ""
+		}
+
+		if bestLocales[i] != locale {
+			locale = ""
+		}
+	}
+
+	// The pattern introducing the email, and the header patterns, must both
+	// vote for the client
+	introduction := patterns[0]
+	if introduction == nil {
+		introduction = patterns[1]
+	}
+
+	if introduction != nil && !parser._VotesFor(introduction, client, locale) {
+		return "", ""
+	}
+
+	headers, headersVote := false, false
+
+	for _, pattern := range patterns[2:] {
+		if len(parser.patterns.Sources[pattern]) > 0 {
+			headers = true
+			headersVote = headersVote || parser._VotesFor(pattern, client, "")
+		}
+	}
+
+	if headers && !headersVote {
+		return "", ""
+	}
+
+	return client, locale
+}
+
+// _VotesFor tells whether pattern was written by client, in locale if not
+// empty
+func (parser *Parser) _VotesFor(pattern *regexp.Regexp, client string, locale string) bool {
+	for _, source := range parser.patterns.Sources[pattern] {
+		if source.Client == client && (len(locale) == 0 || source.Locales == nil || _ContainsString(source.Locales, locale)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// _WrittenIn tells whether a client writes pattern in locale, which is true
+// of a missing pattern
+func (parser *Parser) _WrittenIn(pattern *regexp.Regexp, locale string) bool {
+	if pattern == nil {
+		return true
+	}
+
+	for _, source := range parser.patterns.Sources[pattern] {
+		if source.Locales == nil || _ContainsString(source.Locales, locale) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func _BaseLocale(locale string) string {
+	if i := strings.IndexAny(locale, "-_"); i >= 0 {
+		return locale[:i]
+	}
+
+	return locale
+}
@@ -0,0 +1,127 @@
+package emailforwardparser
+
+import (
+	"strings"
+	"testing"
+
+	regexp "github.com/wasilibs/go-re2"
+)
+
+func TestDetectClient(t *testing.T) {
+	for _, entry := range []struct {
+		Email   string
+		Subject string
+		Client  string
+		Locale  string
+	}{
+		{"apple_mail_de_body", "", "Apple Mail", "de"},
+		{"apple_mail_pt_br_body", "", "Apple Mail", "pt-br"},
+		{"gmail_cs_body", "", "Gmail", "cs"},
+		{"gmail_sk_body", "", "Gmail", "sk"},
+		{"hubspot_ja_body", "", "HubSpot", "ja"},
+		{"ionos_one_and_one_en_body", "", "IONOS by 1 & 1", "en"},
+		{"new_outlook_2019_en_body", "new_outlook_2019_en_subject", "New Outlook 2019", "en"},
+		{"new_outlook_2019_tr_body", "new_outlook_2019_tr_subject", "New Outlook 2019", "tr"},
+		{"outlook_2019_fr_body", "", "Outlook 2019", "fr"},
+		{"outlook_live_body", "outlook_live_fr_subject", "Outlook Live / 365", "fr"},
+		{"outlook_live_en_body_variant_1", "outlook_live_en_subject", "Outlook Live / 365", "en"},
+		{"thunderbird_ro_body", "", "Thunderbird", "ro"},
+		// Outlook 2013 is not a known client: its "FW:" is Outlook 2019's,
+		// its headers Outlook Live's
+		{"outlook_2013_en_body", "outlook_2013_en_subject", "", ""},
+		// Gmail, HubSpot and Missive write the same separator and headers
+		{"gmail_en_body", "", "", ""},
+		{"hubspot_en_body", "", "", ""},
+		{"missive_en_body", "", "", ""},
+		// Gmail writes Danish and Norwegian alike
+		{"gmail_no_body", "", "Gmail", ""},
+		{"yahoo_de_body", "", "Yahoo Mail", "de"},
+		{"yahoo_pt_br_body", "", "Yahoo Mail", "pt-br"},
+	} {
+		email, subject := _Read(entry.Email, entry.Subject)
+		result := Read(email, subject)
+
+		if result.Client != entry.Client || result.Locale != entry.Locale {
+			t.Error(entry.Email, "unexpected client", result.Client, result.Locale)
+		}
+	}
+
+	if result := Read(_TestBody, ""); len(result.Client) > 0 || len(result.Locale) > 0 {
+		t.Error("unexpected client for a message that is not a forward", result.Client, result.Locale)
+	}
+}
+
+// The clients of the fixtures, by the prefix of their name
+var _FixtureClients = map[string]string{
+	"apple_mail":        "Apple Mail",
+	"gmail":             "Gmail",
+	"hubspot":           "HubSpot",
+	"ionos_one_and_one": "IONOS by 1 & 1",
+	"missive":           "Missive",
+	"new_outlook_2019":  "New Outlook 2019",
+	"outlook_2013":      "",
+	"outlook_2019":      "Outlook 2019",
+	"outlook_live":      "Outlook Live / 365",
+	"thunderbird":       "Thunderbird",
+	"unknown":           "",
+	"yahoo":             "Yahoo Mail",
+}
+
+var _FixtureName = regexp.MustCompile(`^(.+?)(?:_([a-z]{2}(?:_br)?))?_body`)
+
+// A fixture is detected as its own client and locale, or as none when the
+// patterns are shared, but never as another
+func TestDetectClientFixtures(t *testing.T) {
+	for _, fixture := range _ReadFixtures(t) {
+		match := _FixtureName.FindStringSubmatch(fixture.Name)
+
+		client, ok := _FixtureClients[match[1]]
+		if !ok {
+			t.Fatal(fixture.Name, "unknown client")
+		}
+
+		locale := strings.Replace(match[2], "_", "-", 1)
+		if locale == "cz" {
+			locale = "cs"
+		}
+
+		result := Read(fixture.Body, fixture.Subject)
+
+		if result.Client != client && len(result.Client) > 0 {
+			t.Error(fixture.Name, "unexpected client", result.Client)
+		}
+
+		if len(locale) > 0 && result.Locale != locale && len(result.Locale) > 0 {
+			t.Error(fixture.Name, "unexpected locale", result.Locale)
+		}
+
+		if len(result.Client) == 0 && len(result.Locale) > 0 {
+			t.Error(fixture.Name, "locale without a client", result.Locale)
+		}
+	}
+}
+
+func TestPatternSources(t *testing.T) {
+	set := DefaultPatternSet()
+
+	for _, field := range set._Fields() {
+		if field.Name == "Mailbox" || field.Name == "MailboxAddress" {
+			continue
+		}
+
+		for _, pattern := range *field.Patterns {
+			sources := _ParseClientSources(pattern.Source)
+
+			// Disclaimers are not written by a client
+			if len(sources) == 0 && field.Name != "Signature" {
+				t.Error(field.Name, "pattern without sources", pattern.Expr)
+			}
+
+			for _, source := range sources {
+				if len(source.Client) == 0 || (source.Locales != nil && len(source.Locales) == 0) {
+					t.Error(field.Name, "invalid source", pattern.Source)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,67 @@
+// Command efp-server serves the parser over HTTP; see package server for the
+// API.
+//
+// Usage:
+//
+//	efp-server [-addr :8080] [-max-body-size 10485760] [-timeout 10s]
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/darnfish/email-forward-parser/server"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "address to listen on")
+	maxBodySize := flag.Int64("max-body-size", 10<<20, "size of the largest request body accepted, in bytes")
+	timeout := flag.Duration("timeout", 10*time.Second, "time allowed to read a request")
+
+	flag.Parse()
+
+	httpServer := &http.Server{
+		Addr:    *addr,
+		Handler: server.NewHandler(server.Options{MaxBodySize: *maxBodySize, Timeout: *timeout}),
+
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       *timeout,
+		WriteTimeout:      *timeout + 5*time.Second,
+		IdleTimeout:       time.Minute,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Shutdown makes ListenAndServe return at once, then waits for the
+	// requests being served
+	shutdown := make(chan struct{})
+
+	go func() {
+		defer close(shutdown)
+
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), *timeout)
+		defer cancel()
+
+		if err := httpServer.Shutdown(shutdownCtx); err != nil {
+			log.Println("efp-server:", err)
+		}
+	}()
+
+	log.Println("efp-server: listening on", *addr)
+
+	if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatal("efp-server: ", err)
+	}
+
+	<-shutdown
+}
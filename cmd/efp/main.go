@@ -0,0 +1,234 @@
+// Command efp parses forwarded emails and prints the original email.
+//
+// Usage:
+//
+//	efp [flags] [file or directory...]
+//
+// Each argument is a raw message (.eml), a plain text body (.txt), an HTML
+// body (.html), or a directory, such as a maildir, whose files are read in
+// turn. Without arguments, or with "-", the input is read from stdin.
+//
+// Flags:
+//
+//	-subject string
+//		subject of the forward, for plain text and HTML bodies
+//	-type string
+//		type of the inputs: auto, message, text or html (default "auto")
+//	-output string
+//		output format: table, json or yaml (default "table")
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+
+	efp "github.com/darnfish/email-forward-parser"
+)
+
+type _Input struct {
+	Path string
+	Data []byte
+}
+
+type _Output struct {
+	Path   string         `json:"path"`
+	Result efp.ReadResult `json:"result"`
+}
+
+func main() {
+	os.Exit(_Run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func _Run(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	flags := flag.NewFlagSet("efp", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+
+	subject := flags.String("subject", "", "subject of the forward, for plain text and HTML bodies")
+	inputType := flags.String("type", "auto", "type of the inputs: auto, message, text or html")
+	output := flags.String("output", "table", "output format: table, json or yaml")
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	write, ok := _Writers[*output]
+	if !ok {
+		fmt.Fprintf(stderr, "efp: unknown output format %q\n", *output)
+		return 2
+	}
+
+	if _, ok := _InputTypes[*inputType]; !ok {
+		fmt.Fprintf(stderr, "efp: unknown input type %q\n", *inputType)
+		return 2
+	}
+
+	paths := flags.Args()
+	if len(paths) == 0 {
+		paths = []string{"-"}
+	}
+
+	status := 0
+	outputs := []_Output{}
+
+	for _, path := range paths {
+		err := _ReadInputs(path, stdin, func(input _Input) error {
+			result, err := _Parse(input, *inputType, *subject)
+			if err != nil {
+				return fmt.Errorf("%s: %w", input.Path, err)
+			}
+
+			outputs = append(outputs, _Output{Path: input.Path, Result: result})
+
+			return nil
+		})
+
+		if err != nil {
+			fmt.Fprintf(stderr, "efp: %s\n", err)
+			status = 1
+		}
+	}
+
+	if err := write(stdout, outputs); err != nil {
+		fmt.Fprintf(stderr, "efp: %s\n", err)
+		return 1
+	}
+
+	return status
+}
+
+// _ReadInputs calls fn with the file at path, each file of the directory at
+// path, or stdin for "-". Hidden files and the tmp directory of maildirs,
+// which holds messages still being delivered, are skipped.
+func _ReadInputs(path string, stdin io.Reader, fn func(_Input) error) error {
+	if path == "-" {
+		data, err := io.ReadAll(stdin)
+		if err != nil {
+			return err
+		}
+
+		return fn(_Input{Path: "-", Data: data})
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	if !info.IsDir() {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		return fn(_Input{Path: path, Data: data})
+	}
+
+	var errs []error
+
+	err = filepath.WalkDir(path, func(name string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if name != path && strings.HasPrefix(entry.Name(), ".") {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if entry.IsDir() {
+			if entry.Name() == "tmp" && _IsMaildir(filepath.Dir(name)) {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		data, err := os.ReadFile(name)
+		if err != nil {
+			errs = append(errs, err)
+			return nil
+		}
+
+		if err := fn(_Input{Path: name, Data: data}); err != nil {
+			errs = append(errs, err)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+func _IsMaildir(path string) bool {
+	for _, name := range []string{"cur", "new", "tmp"} {
+		if info, err := os.Stat(filepath.Join(path, name)); err != nil || !info.IsDir() {
+			return false
+		}
+	}
+
+	return true
+}
+
+var _InputTypes = map[string]bool{"auto": true, "message": true, "text": true, "html": true}
+
+func _Parse(input _Input, inputType string, subject string) (efp.ReadResult, error) {
+	if inputType == "auto" {
+		inputType = _DetectInputType(input)
+	}
+
+	switch inputType {
+	case "message":
+		return efp.ReadMessage(bytes.NewReader(input.Data))
+	case "html":
+		return efp.ReadHTML(string(input.Data), subject), nil
+	}
+
+	return efp.Read(string(input.Data), subject), nil
+}
+
+// The headers of a raw message, one of which is required besides From for
+// an input to be read as one: forwarded bodies may start with "From:" too
+var _MessageHeaders = []string{"Message-Id", "Received", "Mime-Version", "Content-Type", "Return-Path", "Delivered-To"}
+
+func _DetectInputType(input _Input) string {
+	switch strings.ToLower(filepath.Ext(input.Path)) {
+	case ".eml":
+		return "message"
+	case ".txt":
+		return "text"
+	case ".html", ".htm":
+		return "html"
+	}
+
+	message, err := mail.ReadMessage(bytes.NewReader(input.Data))
+	if err == nil && len(message.Header.Get("From")) > 0 {
+		for _, header := range _MessageHeaders {
+			if _, ok := message.Header[header]; ok {
+				return "message"
+			}
+		}
+	}
+
+	trimmed := bytes.TrimSpace(input.Data)
+
+	if bytes.HasPrefix(trimmed, []byte("<")) && bytes.Contains(bytes.ToLower(trimmed), []byte("<html")) {
+		return "html"
+	}
+
+	return "text"
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func _RunTest(t *testing.T, stdin string, args ...string) (string, string, int) {
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+
+	status := _Run(args, strings.NewReader(stdin), stdout, stderr)
+
+	return stdout.String(), stderr.String(), status
+}
+
+func _DecodeOutputs(t *testing.T, stdout string) []_Output {
+	outputs := []_Output{}
+
+	decoder := json.NewDecoder(strings.NewReader(stdout))
+
+	for decoder.More() {
+		output := _Output{}
+
+		if err := decoder.Decode(&output); err != nil {
+			t.Fatal(err)
+		}
+
+		outputs = append(outputs, output)
+	}
+
+	return outputs
+}
+
+func TestRunFiles(t *testing.T) {
+	stdout, stderr, status := _RunTest(t, "", "-output", "json", "../../fixtures/gmail_cs_body.txt", "../../fixtures/apple_mail_en_body.html")
+	if status != 0 {
+		t.Fatal(status, stderr)
+	}
+
+	outputs := _DecodeOutputs(t, stdout)
+
+	if len(outputs) != 2 {
+		t.Fatal("unexpected outputs", outputs)
+	}
+
+	if !outputs[0].Result.Forwarded || outputs[0].Result.Client != "Gmail" || outputs[0].Result.Email.From.Address != "john.doe@acme.com" {
+		t.Error("unexpected result", outputs[0])
+	}
+
+	if !outputs[1].Result.Forwarded || outputs[1].Path != "../../fixtures/apple_mail_en_body.html" {
+		t.Error("unexpected result", outputs[1])
+	}
+}
+
+func TestRunStdin(t *testing.T) {
+	body, err := os.ReadFile("../../fixtures/outlook_2019_en_body.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stdout, stderr, status := _RunTest(t, string(body), "-subject", "FW: Integer consequat non purus")
+	if status != 0 {
+		t.Fatal(status, stderr)
+	}
+
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "FILE") || !strings.Contains(lines[1], "Outlook 2019") || !strings.Contains(lines[1], "Integer consequat non purus") {
+		t.Error("unexpected table", stdout)
+	}
+
+	message := strings.Join([]string{
+		"From: Bessie Berry <bessie.berry@acme.com>",
+		"Subject: Fwd: Integer consequat non purus",
+		"Message-ID: <forward@acme.com>",
+		"",
+		string(body),
+	}, "\r\n")
+
+	stdout, _, _ = _RunTest(t, message, "-output", "json", "-")
+
+	outputs := _DecodeOutputs(t, stdout)
+
+	if len(outputs) != 1 || outputs[0].Result.Email.Subject != "Integer consequat non purus" {
+		t.Error("the message was not read with its subject", outputs)
+	}
+}
+
+func TestRunMaildir(t *testing.T) {
+	body, err := os.ReadFile("../../fixtures/gmail_cs_body.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	message := []byte("From: bessie.berry@acme.com\r\nSubject: Fwd: Integer consequat non purus\r\nMIME-Version: 1.0\r\n\r\n" + string(body))
+
+	maildir := t.TempDir()
+
+	for name, data := range map[string][]byte{
+		"cur/1700000000.M1P1.host:2,S": message,
+		"new/1700000001.M2P2.host":     message,
+		"tmp/1700000002.M3P3.host":     []byte("partial"),
+		".Archive/cur/1700000003":      message,
+	} {
+		path := filepath.Join(maildir, name)
+
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	stdout, stderr, status := _RunTest(t, "", "-output", "yaml", maildir)
+	if status != 0 {
+		t.Fatal(status, stderr)
+	}
+
+	if strings.Count(stdout, "---\n") != 2 || strings.Contains(stdout, "1700000002") || strings.Contains(stdout, "Archive") {
+		t.Error("unexpected files", stdout)
+	}
+
+	if !strings.Contains(stdout, "\n  forwarded: true\n") || !strings.Contains(stdout, `      address: "john.doe@acme.com"`) {
+		t.Error("unexpected YAML", stdout)
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	if _, _, status := _RunTest(t, "", "-output", "xml"); status != 2 {
+		t.Error("unexpected status for an unknown format", status)
+	}
+
+	if _, _, status := _RunTest(t, "", "-type", "pdf"); status != 2 {
+		t.Error("unexpected status for an unknown type", status)
+	}
+
+	stdout, stderr, status := _RunTest(t, "", "-output", "json", "missing.eml", "../../fixtures/gmail_cs_body.txt")
+
+	if status != 1 || !strings.Contains(stderr, "missing.eml") || len(_DecodeOutputs(t, stdout)) != 1 {
+		t.Error("unexpected output for a missing file", status, stderr)
+	}
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+var _Writers = map[string]func(io.Writer, []_Output) error{
+	"table": _WriteTable,
+	"json":  _WriteJSON,
+	"yaml":  _WriteYAML,
+}
+
+// _WriteTable writes one line per input, with the fields support usually
+// needs to look at
+func _WriteTable(w io.Writer, outputs []_Output) error {
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(table, "FILE\tFORWARDED\tKIND\tCLIENT\tLOCALE\tCONFIDENCE\tFROM\tDATE\tSUBJECT")
+
+	for _, output := range outputs {
+		result := output.Result
+
+		fmt.Fprintf(table, "%s\t%t\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			output.Path,
+			result.Forwarded,
+			result.Kind,
+			_Cell(result.Client),
+			_Cell(result.Locale),
+			result.Matches.Confidence(),
+			_Cell(result.Email.From.String()),
+			_Cell(result.Email.Date),
+			_Cell(result.Email.Subject),
+		)
+	}
+
+	return table.Flush()
+}
+
+func _Cell(value string) string {
+	value = strings.Join(strings.Fields(value), " ")
+
+	if len(value) == 0 {
+		return "-"
+	}
+
+	return value
+}
+
+// _WriteJSON writes one JSON document per input
+func _WriteJSON(w io.Writer, outputs []_Output) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+
+	for _, output := range outputs {
+		if err := encoder.Encode(output); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// _WriteYAML writes one YAML document per input, converted from its JSON
+// form so that both formats hold the same fields
+func _WriteYAML(w io.Writer, outputs []_Output) error {
+	for _, output := range outputs {
+		data, err := json.Marshal(output)
+		if err != nil {
+			return err
+		}
+
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+
+		buffer := &bytes.Buffer{}
+		buffer.WriteString("---\n")
+
+		if err := _WriteYAMLValue(buffer, decoder, 0); err != nil {
+			return err
+		}
+
+		if _, err := w.Write(buffer.Bytes()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// _WriteYAMLValue writes the next JSON value of decoder in YAML block style,
+// keeping the order of object keys. Strings are written as double-quoted
+// scalars, whose escapes are those of JSON.
+func _WriteYAMLValue(w *bytes.Buffer, decoder *json.Decoder, indent int) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+
+	switch token {
+	case json.Delim('{'):
+		if !decoder.More() {
+			w.WriteString(" {}\n")
+			_, err := decoder.Token()
+			return err
+		}
+
+		if indent > 0 || w.Len() > len("---\n") {
+			w.WriteString("\n")
+		}
+
+		for decoder.More() {
+			key, err := decoder.Token()
+			if err != nil {
+				return err
+			}
+
+			w.WriteString(strings.Repeat("  ", indent) + fmt.Sprint(key) + ":")
+
+			if err := _WriteYAMLValue(w, decoder, indent+1); err != nil {
+				return err
+			}
+		}
+
+		_, err := decoder.Token()
+		return err
+
+	case json.Delim('['):
+		if !decoder.More() {
+			w.WriteString(" []\n")
+			_, err := decoder.Token()
+			return err
+		}
+
+		w.WriteString("\n")
+
+		for decoder.More() {
+			w.WriteString(strings.Repeat("  ", indent) + "-")
+
+			if err := _WriteYAMLValue(w, decoder, indent+1); err != nil {
+				return err
+			}
+		}
+
+		_, err := decoder.Token()
+		return err
+	}
+
+	switch value := token.(type) {
+	case string:
+		quoted := &bytes.Buffer{}
+
+		encoder := json.NewEncoder(quoted)
+		encoder.SetEscapeHTML(false)
+
+		if err := encoder.Encode(value); err != nil {
+			return err
+		}
+
+		w.WriteString(" " + quoted.String())
+	case nil:
+		w.WriteString(" null\n")
+	default:
+		w.WriteString(" " + fmt.Sprint(value) + "\n")
+	}
+
+	return nil
+}
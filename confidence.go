@@ -0,0 +1,198 @@
+package emailforwardparser
+
+import (
+	regexp "github.com/wasilibs/go-re2"
+)
+
+// Confidence tells how reliably a field of a forward was found.
+type Confidence int
+
+const (
+	// The field was not found.
+	ConfidenceNone Confidence = iota
+	// The field was guessed: found by a lax pattern in a header block that
+	// is not split into lines, or the forward has no separator.
+	ConfidenceLow
+	// The field was found in a line that holds other parts of the forward,
+	// such as the separator of Outlook 2019.
+	ConfidenceMedium
+	// The field was found on its own line, by a strict pattern, or in the
+	// headers of an attached message.
+	ConfidenceHigh
+)
+
+func (confidence Confidence) String() string {
+	switch confidence {
+	case ConfidenceLow:
+		return "low"
+	case ConfidenceMedium:
+		return "medium"
+	case ConfidenceHigh:
+		return "high"
+	}
+
+	return "none"
+}
+
+// The strategies that do not use a pattern
+const (
+	// StrategyAttachment is the strategy of the fields of an original email
+	// attached to the message (message/rfc822), as read by ReadMessage.
+	StrategyAttachment = "Attachment"
+)
+
+// FieldMatch tells how a field of a forward was found.
+type FieldMatch struct {
+	Confidence Confidence `json:"confidence"`
+
+	// Strategy names the PatternSet field whose pattern found the field,
+	// such as "OriginalFrom", "SeparatorWithInformation" (the sender and
+	// date written in the separator) or "OriginalFromLax", or is
+	// StrategyAttachment. It is empty if the field was not found.
+	Strategy string `json:"strategy"`
+
+	// Pattern is the pattern that matched, if any.
+	Pattern Pattern `json:"pattern"`
+}
+
+// ReadMatches holds how each field of a forward was found. Separator is the
+// line the forwarded email starts at: the separator, or, when there is none,
+// the "From" header of the original email (found by an "OriginalFrom"
+// pattern, with a low confidence). Body is the header line that ends the
+// header block; when there is none, the body has a low confidence and no
+// strategy.
+type ReadMatches struct {
+	Separator FieldMatch `json:"separator"`
+
+	From       FieldMatch `json:"from"`
+	To         FieldMatch `json:"to"`
+	CC         FieldMatch `json:"cc"`
+	BCC        FieldMatch `json:"bcc"`
+	ReplyTo    FieldMatch `json:"replyTo"`
+	Subject    FieldMatch `json:"subject"`
+	Date       FieldMatch `json:"date"`
+	MessageID  FieldMatch `json:"messageId"`
+	InReplyTo  FieldMatch `json:"inReplyTo"`
+	References FieldMatch `json:"references"`
+
+	Body FieldMatch `json:"body"`
+}
+
+// Confidence returns the lowest confidence of the separator, sender, subject
+// and date, the fields every forward has.
+func (matches ReadMatches) Confidence() Confidence {
+	confidence := ConfidenceHigh
+
+	for _, match := range []FieldMatch{matches.Separator, matches.From, matches.Subject, matches.Date} {
+		if match.Confidence < confidence {
+			confidence = match.Confidence
+		}
+	}
+
+	return confidence
+}
+
+var _StrategyConfidences = map[string]Confidence{
+	"Subject":                  ConfidenceHigh,
+	"Separator":                ConfidenceHigh,
+	"SeparatorWithInformation": ConfidenceMedium,
+	"OriginalSubject":          ConfidenceHigh,
+	"OriginalSubjectLax":       ConfidenceLow,
+	"OriginalFrom":             ConfidenceHigh,
+	"OriginalFromLax":          ConfidenceLow,
+	"OriginalTo":               ConfidenceHigh,
+	"OriginalToLax":            ConfidenceLow,
+	"OriginalReplyTo":          ConfidenceHigh,
+	"OriginalCC":               ConfidenceHigh,
+	"OriginalCCLax":            ConfidenceLow,
+	"OriginalBCC":              ConfidenceHigh,
+	"OriginalDate":             ConfidenceHigh,
+	"OriginalDateLax":          ConfidenceLow,
+	"OriginalMessageID":        ConfidenceHigh,
+	"OriginalInReplyTo":        ConfidenceHigh,
+	"OriginalReferences":       ConfidenceHigh,
+}
+
+func (parser *Parser) _FieldMatch(re *regexp.Regexp) FieldMatch {
+	if re == nil {
+		return FieldMatch{}
+	}
+
+	strategy := parser.patterns.Names[re]
+
+	return FieldMatch{
+		Confidence: _StrategyConfidences[strategy],
+		Strategy:   strategy,
+		Pattern:    parser.patterns.Definitions[re],
+	}
+}
+
+func (parser *Parser) _NewReadMatches(body _ParseBodyResult, subject *regexp.Regexp, email _ParseOriginalEmailResult) ReadMatches {
+	matches := ReadMatches{
+		Separator: parser._FieldMatch(body.Separator),
+
+		From:       parser._FieldMatch(email.Headers.From),
+		To:         parser._FieldMatch(email.Headers.To),
+		CC:         parser._FieldMatch(email.Headers.CC),
+		BCC:        parser._FieldMatch(email.Headers.BCC),
+		ReplyTo:    parser._FieldMatch(email.Headers.ReplyTo),
+		Subject:    parser._FieldMatch(email.Headers.Subject),
+		Date:       parser._FieldMatch(email.Headers.Date),
+		MessageID:  parser._FieldMatch(email.Headers.MessageID),
+		InReplyTo:  parser._FieldMatch(email.Headers.InReplyTo),
+		References: parser._FieldMatch(email.Headers.References),
+
+		Body: parser._FieldMatch(email.BodyPattern),
+	}
+
+	if body.Separator == nil && body.FromSplit != nil {
+		matches.Separator = parser._FieldMatch(body.FromSplit)
+		matches.Separator.Confidence = ConfidenceLow
+	}
+
+	if email.Headers.From == nil && (len(email.From.Name) > 0 || len(email.From.Address) > 0) {
+		matches.From = parser._FieldMatch(email.Information)
+	}
+
+	if email.Headers.Date == nil && len(email.Date) > 0 {
+		matches.Date = parser._FieldMatch(email.Information)
+	}
+
+	if subject != nil {
+		matches.Subject = parser._FieldMatch(subject)
+	}
+
+	// The end of the header block was not found, so the body holds it
+	if email.BodyPattern == nil && len(email.Body) > 0 {
+		matches.Body.Confidence = ConfidenceLow
+	}
+
+	return matches
+}
+
+func _AttachmentReadMatches(email ReadResultEmail) ReadMatches {
+	match := func(found bool) FieldMatch {
+		if !found {
+			return FieldMatch{}
+		}
+
+		return FieldMatch{Confidence: ConfidenceHigh, Strategy: StrategyAttachment}
+	}
+
+	return ReadMatches{
+		Separator: match(true),
+
+		From:       match(len(email.From.Name) > 0 || len(email.From.Address) > 0),
+		To:         match(len(email.To) > 0),
+		CC:         match(len(email.CC) > 0),
+		BCC:        match(len(email.BCC) > 0),
+		ReplyTo:    match(len(email.ReplyTo) > 0),
+		Subject:    match(len(email.Subject) > 0),
+		Date:       match(len(email.Date) > 0),
+		MessageID:  match(len(email.MessageID) > 0),
+		InReplyTo:  match(len(email.InReplyTo) > 0),
+		References: match(len(email.References) > 0),
+
+		Body: match(true),
+	}
+}
@@ -0,0 +1,67 @@
+package emailforwardparser
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReadMatches(t *testing.T) {
+	result := _ReadAndParse("gmail_en_body", "")
+
+	if result.Matches.Confidence() != ConfidenceHigh {
+		t.Error("unexpected confidence", result.Matches.Confidence())
+	}
+
+	for name, match := range map[string]FieldMatch{
+		"Separator":       result.Matches.Separator,
+		"OriginalFrom":    result.Matches.From,
+		"OriginalTo":      result.Matches.To,
+		"OriginalCC":      result.Matches.CC,
+		"OriginalSubject": result.Matches.Subject,
+		"OriginalDate":    result.Matches.Date,
+	} {
+		if match.Confidence != ConfidenceHigh || match.Strategy != name || !strings.Contains(match.Pattern.Source, "Gmail") {
+			t.Error(name, "unexpected match", match)
+		}
+	}
+
+	if result.Matches.BCC != (FieldMatch{}) {
+		t.Error("unexpected BCC match", result.Matches.BCC)
+	}
+}
+
+func TestReadMatchesFallbacks(t *testing.T) {
+	// The sender and date are written in the separator
+	email, _ := _Read("outlook_2019_en_body", "")
+	result := Read(email, "FW: "+_TestSubject)
+
+	if result.Matches.From.Strategy != "SeparatorWithInformation" || result.Matches.From.Confidence != ConfidenceMedium || result.Matches.Date.Strategy != "SeparatorWithInformation" {
+		t.Error("unexpected sender and date matches", result.Matches.From, result.Matches.Date)
+	}
+
+	if result.Matches.Subject.Strategy != "Subject" || result.Matches.Confidence() != ConfidenceMedium {
+		t.Error("unexpected matches", result.Matches.Subject, result.Matches.Confidence())
+	}
+
+	// The headers are not split into lines
+	result = _ReadAndParse("yahoo_en_body", "")
+
+	if result.Matches.To.Strategy != "OriginalToLax" || result.Matches.Date.Strategy != "OriginalDateLax" || result.Matches.Confidence() != ConfidenceLow {
+		t.Error("unexpected lax matches", result.Matches.To, result.Matches.Date, result.Matches.Confidence())
+	}
+
+	// There is no separator
+	result = _ReadAndParse("new_outlook_2019_en_body", "new_outlook_2019_en_subject")
+
+	if result.Matches.Separator.Strategy != "OriginalFrom" || result.Matches.Separator.Confidence != ConfidenceLow || result.Matches.From.Confidence != ConfidenceHigh {
+		t.Error("unexpected separator match", result.Matches.Separator, result.Matches.From)
+	}
+}
+
+func TestReadMatchesNotForwarded(t *testing.T) {
+	result := Read(_TestMessage, "")
+
+	if result.Matches != (ReadMatches{}) || result.Matches.Confidence() != ConfidenceNone {
+		t.Error("unexpected matches", result.Matches)
+	}
+}
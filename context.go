@@ -0,0 +1,162 @@
+package emailforwardparser
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+)
+
+// Limits bounds the input of ReadContext. A zero limit disables the check.
+type Limits struct {
+	// MaxBodySize is the size of the largest body read, in bytes.
+	MaxBodySize int
+	// MaxSubjectSize is the size of the largest subject read, in bytes.
+	MaxSubjectSize int
+}
+
+// DefaultLimits are the limits of the parsers returned by NewParser.
+var DefaultLimits = Limits{
+	MaxBodySize:    10 << 20,
+	MaxSubjectSize: 64 << 10,
+}
+
+// InputTooLargeError is returned by ReadContext when the body or subject
+// exceeds the limits of the parser.
+type InputTooLargeError struct {
+	// Input is "body" or "subject"
+	Input string
+	// Size is at most Limit+1 for the messages of ReadMessageContext, which
+	// stops reading there
+	Size  int
+	Limit int
+}
+
+func (err *InputTooLargeError) Error() string {
+	return fmt.Sprintf("emailforwardparser: %s of %d bytes exceeds the limit of %d bytes", err.Input, err.Size, err.Limit)
+}
+
+// Is makes errors.Is(err, ErrInputTooLarge) true.
+func (err *InputTooLargeError) Is(target error) bool {
+	return target == ErrInputTooLarge
+}
+
+// WithLimits returns a copy of the parser using the given limits in
+// ReadContext.
+func (parser *Parser) WithLimits(limits Limits) *Parser {
+	limited := *parser
+	limited.limits = limits
+
+	return &limited
+}
+
+// ReadContext is like Read, for untrusted input: it returns an
+// *InputTooLargeError if the body or subject exceed DefaultLimits, and stops
+// reading when ctx is done, returning ctx.Err().
+func ReadContext(ctx context.Context, body string, subject string) (ReadResult, error) {
+	return _DefaultParser.ReadContext(ctx, body, subject)
+}
+
+// ReadContext is like the package-level ReadContext, using the parser's
+// patterns and limits.
+func (parser *Parser) ReadContext(ctx context.Context, body string, subject string) (ReadResult, error) {
+	bounded, err := parser._Bound(ctx, body, subject)
+	if err != nil {
+		return ReadResult{}, err
+	}
+
+	result, _ := bounded._Read(body, subject, false)
+
+	// Patterns are no longer tried once ctx is done, so the result may be
+	// partial
+	if err := ctx.Err(); err != nil {
+		return ReadResult{}, err
+	}
+
+	return result, nil
+}
+
+// ReadHTMLContext is ReadHTML for untrusted input, as ReadContext is Read.
+// The body is rendered to text before the patterns are tried, which takes
+// linear time.
+func ReadHTMLContext(ctx context.Context, body string, subject string) (ReadResult, error) {
+	return _DefaultParser.ReadHTMLContext(ctx, body, subject)
+}
+
+// ReadHTMLContext is like the package-level ReadHTMLContext, using the
+// parser's patterns and limits.
+func (parser *Parser) ReadHTMLContext(ctx context.Context, body string, subject string) (ReadResult, error) {
+	bounded, err := parser._Bound(ctx, body, subject)
+	if err != nil {
+		return ReadResult{}, err
+	}
+
+	result := bounded.ReadHTML(body, subject)
+
+	if err := ctx.Err(); err != nil {
+		return ReadResult{}, err
+	}
+
+	return result, nil
+}
+
+// ReadMessageContext is ReadMessage for untrusted input, as ReadContext is
+// Read: MaxBodySize bounds the size of the raw message, which is read
+// before its parts are decoded.
+func ReadMessageContext(ctx context.Context, r io.Reader) (ReadResult, error) {
+	return _DefaultParser.ReadMessageContext(ctx, r)
+}
+
+// ReadMessageContext is like the package-level ReadMessageContext, using the
+// parser's patterns and limits.
+func (parser *Parser) ReadMessageContext(ctx context.Context, r io.Reader) (ReadResult, error) {
+	if limit := parser.limits.MaxBodySize; limit > 0 {
+		r = io.LimitReader(r, int64(limit)+1)
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return ReadResult{}, err
+	}
+
+	bounded, err := parser._Bound(ctx, string(data), "")
+	if err != nil {
+		return ReadResult{}, err
+	}
+
+	result, err := bounded.ReadMessage(bytes.NewReader(data))
+
+	if err := ctx.Err(); err != nil {
+		return ReadResult{}, err
+	}
+
+	return result, err
+}
+
+// _Bound returns a copy of the parser that stops trying patterns when ctx is
+// done, or the error if the body or subject exceed its limits or ctx is
+// already done
+func (parser *Parser) _Bound(ctx context.Context, body string, subject string) (*Parser, error) {
+	if limit := parser.limits.MaxBodySize; limit > 0 && len(body) > limit {
+		return nil, &InputTooLargeError{Input: "body", Size: len(body), Limit: limit}
+	}
+
+	if limit := parser.limits.MaxSubjectSize; limit > 0 && len(subject) > limit {
+		return nil, &InputTooLargeError{Input: "subject", Size: len(subject), Limit: limit}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	bounded := *parser
+	bounded.ctx = ctx
+
+	return &bounded, nil
+}
+
+// _Done tells whether the context of ReadContext is done, in which case the
+// remaining patterns are not tried
+func (parser *Parser) _Done() bool {
+	return parser.ctx != nil && parser.ctx.Err() != nil
+}
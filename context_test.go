@@ -0,0 +1,135 @@
+package emailforwardparser
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// _CountdownContext is done after its Err method was called a given number
+// of times
+type _CountdownContext struct {
+	context.Context
+
+	calls int
+}
+
+func (ctx *_CountdownContext) Err() error {
+	if ctx.calls <= 0 {
+		return context.DeadlineExceeded
+	}
+
+	ctx.calls--
+
+	return nil
+}
+
+func TestReadContext(t *testing.T) {
+	email, subject := _Read("gmail_en_body", "")
+
+	result, err := ReadContext(context.Background(), email, subject)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(result, Read(email, subject)) {
+		t.Error("ReadContext and Read differ")
+	}
+}
+
+func TestReadContextDone(t *testing.T) {
+	email, _ := _Read("gmail_en_body", "")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := ReadContext(ctx, email, ""); !errors.Is(err, context.Canceled) {
+		t.Error("unexpected error", err)
+	}
+
+	// Done while reading the header block, once the separator was found
+	result, err := ReadContext(&_CountdownContext{Context: context.Background(), calls: 5}, email, "")
+
+	if !errors.Is(err, context.DeadlineExceeded) || result.Forwarded {
+		t.Error("unexpected result", result, err)
+	}
+}
+
+func TestReadContextLimits(t *testing.T) {
+	email, _ := _Read("gmail_en_body", "")
+
+	_, err := ReadContext(context.Background(), strings.Repeat(email, (DefaultLimits.MaxBodySize/len(email))+1), "")
+
+	tooLarge := &InputTooLargeError{}
+
+	if !errors.As(err, &tooLarge) || tooLarge.Input != "body" || tooLarge.Limit != DefaultLimits.MaxBodySize {
+		t.Fatal("unexpected error", err)
+	}
+
+	parser := _DefaultParser.WithLimits(Limits{MaxSubjectSize: 8})
+
+	if _, err := parser.ReadContext(context.Background(), email, "Fwd: "+_TestSubject); !errors.As(err, &tooLarge) || tooLarge.Input != "subject" {
+		t.Error("unexpected error", err)
+	}
+
+	if _, err := parser.ReadContext(context.Background(), strings.Repeat(email, 2), "Fwd:"); err != nil {
+		t.Error("unexpected error", err)
+	}
+
+	if _DefaultParser.limits != DefaultLimits {
+		t.Error("WithLimits changed the parser")
+	}
+}
+
+func TestReadHTMLContext(t *testing.T) {
+	body := _ReadHTMLFixture("gmail_en_body")
+
+	result, err := ReadHTMLContext(context.Background(), body, "")
+	if err != nil || !reflect.DeepEqual(result, ReadHTML(body, "")) {
+		t.Error("ReadHTMLContext and ReadHTML differ", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := ReadHTMLContext(ctx, body, ""); !errors.Is(err, context.Canceled) {
+		t.Error("unexpected error", err)
+	}
+
+	if _, err := ReadHTMLContext(&_CountdownContext{Context: context.Background(), calls: 5}, body, ""); !errors.Is(err, context.DeadlineExceeded) {
+		t.Error("unexpected error", err)
+	}
+
+	parser := _DefaultParser.WithLimits(Limits{MaxBodySize: 64})
+	tooLarge := &InputTooLargeError{}
+
+	if _, err := parser.ReadHTMLContext(context.Background(), body, ""); !errors.As(err, &tooLarge) || tooLarge.Input != "body" {
+		t.Error("unexpected error", err)
+	}
+}
+
+func TestReadMessageContext(t *testing.T) {
+	email, _ := _Read("gmail_en_body", "")
+	message := "Subject: Fwd: " + _TestSubject + "\r\n\r\n" + email
+
+	result, err := ReadMessageContext(context.Background(), strings.NewReader(message))
+	if err != nil || !result.Forwarded || result.Email.From.Address != "john.doe@acme.com" {
+		t.Error("unexpected result", result, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := ReadMessageContext(ctx, strings.NewReader(message)); !errors.Is(err, context.Canceled) {
+		t.Error("unexpected error", err)
+	}
+
+	parser := _DefaultParser.WithLimits(Limits{MaxBodySize: 64})
+	tooLarge := &InputTooLargeError{}
+
+	if _, err := parser.ReadMessageContext(context.Background(), strings.NewReader(message)); !errors.As(err, &tooLarge) || tooLarge.Size != 65 {
+		t.Error("unexpected error", err)
+	}
+}
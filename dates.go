@@ -0,0 +1,471 @@
+package emailforwardparser
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	regexp "github.com/wasilibs/go-re2"
+)
+
+// DateAmbiguity flags the parts of a date that had to be guessed by ParseDate.
+type DateAmbiguity int
+
+const (
+	// DateAmbiguousOrder is set when the day and month were both numeric and
+	// could have been swapped (e.g. "02/10/2023").
+	DateAmbiguousOrder DateAmbiguity = 1 << iota
+	// DateMissingTimezone is set when the date had no timezone; it is then
+	// assumed to be UTC.
+	DateMissingTimezone
+	// DateMissingTime is set when the date had no time of day; it is then
+	// assumed to be midnight.
+	DateMissingTime
+)
+
+// ErrInvalidDate is returned by ParseDate when no date could be recognized.
+var ErrInvalidDate = errors.New("emailforwardparser: invalid date")
+
+type _DateLocale struct {
+	Name       string
+	Months     [12][]string
+	Weekdays   []string
+	Connectors []string
+}
+
+var _DateLocales = []_DateLocale{
+	{
+		Name:       "en",
+		Months:     [12][]string{{"january", "jan"}, {"february", "feb"}, {"march", "mar"}, {"april", "apr"}, {"may"}, {"june", "jun"}, {"july", "jul"}, {"august", "aug"}, {"september", "sep", "sept"}, {"october", "oct"}, {"november", "nov"}, {"december", "dec"}},
+		Weekdays:   []string{"monday", "mon", "tuesday", "tue", "tues", "wednesday", "wed", "thursday", "thu", "thur", "friday", "fri", "saturday", "sat", "sunday", "sun"},
+		Connectors: []string{"at", "am", "pm"},
+	},
+	{
+		Name:       "cs",
+		Months:     [12][]string{{"leden", "ledna", "led"}, {"únor", "února", "úno"}, {"březen", "března", "bře"}, {"duben", "dubna", "dub"}, {"květen", "května", "kvě"}, {"červen", "června", "čvn"}, {"červenec", "července", "čvc"}, {"srpen", "srpna", "srp"}, {"září", "zář"}, {"říjen", "října", "říj"}, {"listopad", "listopadu", "lis"}, {"prosinec", "prosince", "pro"}},
+		Weekdays:   []string{"pondělí", "po", "úterý", "út", "středa", "st", "čtvrtek", "čt", "pátek", "pá", "sobota", "so", "neděle", "ne"},
+		Connectors: []string{"v", "seč", "selč"},
+	},
+	{
+		Name:       "da",
+		Months:     [12][]string{{"januar", "jan"}, {"februar", "feb"}, {"marts", "mar"}, {"april", "apr"}, {"maj"}, {"juni", "jun"}, {"juli", "jul"}, {"august", "aug"}, {"september", "sep"}, {"oktober", "okt"}, {"november", "nov"}, {"december", "dec"}},
+		Weekdays:   []string{"mandag", "man", "tirsdag", "tirs", "onsdag", "ons", "torsdag", "tors", "fredag", "fre", "lørdag", "lør", "søndag", "søn"},
+		Connectors: []string{"kl", "den"},
+	},
+	{
+		Name:       "de",
+		Months:     [12][]string{{"januar", "jan"}, {"februar", "feb"}, {"märz", "mär"}, {"april", "apr"}, {"mai"}, {"juni", "jun"}, {"juli", "jul"}, {"august", "aug"}, {"september", "sep", "sept"}, {"oktober", "okt"}, {"november", "nov"}, {"dezember", "dez"}},
+		Weekdays:   []string{"montag", "mo", "dienstag", "di", "mittwoch", "mi", "donnerstag", "do", "freitag", "fr", "samstag", "sa", "sonntag", "so"},
+		Connectors: []string{"um", "uhr", "mez", "mesz", "oesz", "oez"},
+	},
+	{
+		Name:       "es",
+		Months:     [12][]string{{"enero", "ene"}, {"febrero", "feb"}, {"marzo", "mar"}, {"abril", "abr"}, {"mayo", "may"}, {"junio", "jun"}, {"julio", "jul"}, {"agosto", "ago"}, {"septiembre", "setiembre", "sep", "sept"}, {"octubre", "oct"}, {"noviembre", "nov"}, {"diciembre", "dic"}},
+		Weekdays:   []string{"lunes", "lun", "martes", "mar", "miércoles", "mié", "jueves", "jue", "viernes", "vie", "sábado", "sáb", "domingo", "dom"},
+		Connectors: []string{"de", "a", "las"},
+	},
+	{
+		Name:       "et",
+		Months:     [12][]string{{"jaanuar", "jaan"}, {"veebruar", "veebr"}, {"märts"}, {"aprill", "apr"}, {"mai"}, {"juuni"}, {"juuli"}, {"august", "aug"}, {"september", "sept"}, {"oktoober", "okt"}, {"november", "nov"}, {"detsember", "dets"}},
+		Weekdays:   []string{"esmaspäev", "e", "teisipäev", "t", "kolmapäev", "k", "neljapäev", "n", "reede", "r", "laupäev", "l", "pühapäev", "p"},
+		Connectors: []string{"kell"},
+	},
+	{
+		Name:       "fi",
+		Months:     [12][]string{{"tammikuuta", "tammikuu", "tammik"}, {"helmikuuta", "helmikuu", "helmik"}, {"maaliskuuta", "maaliskuu", "maalisk"}, {"huhtikuuta", "huhtikuu", "huhtik"}, {"toukokuuta", "toukokuu", "toukok"}, {"kesäkuuta", "kesäkuu", "kesäk"}, {"heinäkuuta", "heinäkuu", "heinäk"}, {"elokuuta", "elokuu", "elok"}, {"syyskuuta", "syyskuu", "syysk"}, {"lokakuuta", "lokakuu", "lokak"}, {"marraskuuta", "marraskuu", "marrask"}, {"joulukuuta", "joulukuu", "jouluk"}},
+		Weekdays:   []string{"maanantai", "maanantaina", "ma", "tiistai", "tiistaina", "ti", "keskiviikko", "keskiviikkona", "ke", "torstai", "torstaina", "to", "perjantai", "perjantaina", "pe", "lauantai", "lauantaina", "la", "sunnuntai", "sunnuntaina", "su"},
+		Connectors: []string{"klo"},
+	},
+	{
+		Name:       "fr",
+		Months:     [12][]string{{"janvier", "janv"}, {"février", "févr"}, {"mars"}, {"avril", "avr"}, {"mai"}, {"juin"}, {"juillet", "juil"}, {"août"}, {"septembre", "sept"}, {"octobre", "oct"}, {"novembre", "nov"}, {"décembre", "déc"}},
+		Weekdays:   []string{"lundi", "lun", "mardi", "mar", "mercredi", "mer", "jeudi", "jeu", "vendredi", "ven", "samedi", "sam", "dimanche", "dim"},
+		Connectors: []string{"à"},
+	},
+	{
+		Name:       "hr",
+		Months:     [12][]string{{"siječanj", "siječnja", "sij"}, {"veljača", "veljače", "velj"}, {"ožujak", "ožujka", "ožu"}, {"travanj", "travnja", "tra"}, {"svibanj", "svibnja", "svi"}, {"lipanj", "lipnja", "lip"}, {"srpanj", "srpnja", "srp"}, {"kolovoz", "kolovoza", "kol"}, {"rujan", "rujna", "ruj"}, {"listopad", "listopada", "lis"}, {"studeni", "studenoga", "studenog", "stu"}, {"prosinac", "prosinca", "pro"}},
+		Weekdays:   []string{"ponedjeljak", "pon", "utorak", "uto", "srijeda", "sri", "četvrtak", "čet", "petak", "pet", "subota", "sub", "nedjelja", "ned"},
+		Connectors: []string{"u"},
+	},
+	{
+		Name:       "hu",
+		Months:     [12][]string{{"január", "jan"}, {"február", "febr"}, {"március", "márc"}, {"április", "ápr"}, {"május", "máj"}, {"június", "jún"}, {"július", "júl"}, {"augusztus", "aug"}, {"szeptember", "szept"}, {"október", "okt"}, {"november", "nov"}, {"december", "dec"}},
+		Weekdays:   []string{"hétfő", "h", "kedd", "k", "szerda", "sze", "csütörtök", "cs", "péntek", "p", "szombat", "szo", "vasárnap", "v"},
+		Connectors: []string{},
+	},
+	{
+		Name:       "it",
+		Months:     [12][]string{{"gennaio", "gen"}, {"febbraio", "feb"}, {"marzo", "mar"}, {"aprile", "apr"}, {"maggio", "mag"}, {"giugno", "giu"}, {"luglio", "lug"}, {"agosto", "ago"}, {"settembre", "set"}, {"ottobre", "ott"}, {"novembre", "nov"}, {"dicembre", "dic"}},
+		Weekdays:   []string{"lunedì", "lun", "martedì", "mar", "mercoledì", "mer", "giovedì", "gio", "venerdì", "ven", "sabato", "sab", "domenica", "dom"},
+		Connectors: []string{"alle", "ore"},
+	},
+	{
+		Name:       "nl",
+		Months:     [12][]string{{"januari", "jan"}, {"februari", "feb"}, {"maart", "mrt"}, {"april", "apr"}, {"mei"}, {"juni", "jun"}, {"juli", "jul"}, {"augustus", "aug"}, {"september", "sep", "sept"}, {"oktober", "okt"}, {"november", "nov"}, {"december", "dec"}},
+		Weekdays:   []string{"maandag", "ma", "dinsdag", "di", "woensdag", "wo", "donderdag", "do", "vrijdag", "vr", "zaterdag", "za", "zondag", "zo"},
+		Connectors: []string{"om"},
+	},
+	{
+		Name:       "no",
+		Months:     [12][]string{{"januar", "jan"}, {"februar", "feb"}, {"mars", "mar"}, {"april", "apr"}, {"mai"}, {"juni", "jun"}, {"juli", "jul"}, {"august", "aug"}, {"september", "sep"}, {"oktober", "okt"}, {"november", "nov"}, {"desember", "des"}},
+		Weekdays:   []string{"mandag", "man", "tirsdag", "tir", "onsdag", "ons", "torsdag", "tor", "fredag", "fre", "lørdag", "lør", "søndag", "søn"},
+		Connectors: []string{"kl"},
+	},
+	{
+		Name:       "pl",
+		Months:     [12][]string{{"styczeń", "stycznia", "sty"}, {"luty", "lutego", "lut"}, {"marzec", "marca", "mar"}, {"kwiecień", "kwietnia", "kwi"}, {"maj", "maja"}, {"czerwiec", "czerwca", "cze"}, {"lipiec", "lipca", "lip"}, {"sierpień", "sierpnia", "sie"}, {"wrzesień", "września", "wrz"}, {"październik", "października", "paź"}, {"listopad", "listopada", "lis"}, {"grudzień", "grudnia", "gru"}},
+		Weekdays:   []string{"poniedziałek", "pon", "wtorek", "wt", "środa", "śr", "czwartek", "czw", "piątek", "pt", "sobota", "sob", "niedziela", "niedz"},
+		Connectors: []string{"o", "użytkownik"},
+	},
+	{
+		Name:       "pt",
+		Months:     [12][]string{{"janeiro", "jan"}, {"fevereiro", "fev"}, {"março", "mar"}, {"abril", "abr"}, {"maio", "mai"}, {"junho", "jun"}, {"julho", "jul"}, {"agosto", "ago"}, {"setembro", "set"}, {"outubro", "out"}, {"novembro", "nov"}, {"dezembro", "dez"}},
+		Weekdays:   []string{"segunda-feira", "segunda", "seg", "terça-feira", "terça", "ter", "quarta-feira", "quarta", "qua", "quinta-feira", "quinta", "qui", "sexta-feira", "sexta", "sex", "sábado", "sáb", "domingo", "dom"},
+		Connectors: []string{"de", "às", "à", "s"},
+	},
+	{
+		Name:       "ro",
+		Months:     [12][]string{{"ianuarie", "ian"}, {"februarie", "feb"}, {"martie", "mar"}, {"aprilie", "apr"}, {"mai"}, {"iunie", "iun"}, {"iulie", "iul"}, {"august", "aug"}, {"septembrie", "sept"}, {"octombrie", "oct"}, {"noiembrie", "nov"}, {"decembrie", "dec"}},
+		Weekdays:   []string{"luni", "lun", "marți", "mar", "miercuri", "mie", "joi", "vineri", "vin", "sâmbătă", "sâm", "duminică", "dum"},
+		Connectors: []string{"la"},
+	},
+	{
+		Name:       "ru",
+		Months:     [12][]string{{"январь", "января", "янв"}, {"февраль", "февраля", "фев"}, {"март", "марта", "мар"}, {"апрель", "апреля", "апр"}, {"май", "мая"}, {"июнь", "июня", "июн"}, {"июль", "июля", "июл"}, {"август", "августа", "авг"}, {"сентябрь", "сентября", "сен", "сент"}, {"октябрь", "октября", "окт"}, {"ноябрь", "ноября", "ноя", "нояб"}, {"декабрь", "декабря", "дек"}},
+		Weekdays:   []string{"понедельник", "пн", "вторник", "вт", "среда", "ср", "четверг", "чт", "пятница", "пт", "суббота", "сб", "воскресенье", "вс"},
+		Connectors: []string{"г", "в"},
+	},
+	{
+		Name:       "sk",
+		Months:     [12][]string{{"január", "januára", "jan"}, {"február", "februára", "feb"}, {"marec", "marca", "mar"}, {"apríl", "apríla", "apr"}, {"máj", "mája"}, {"jún", "júna"}, {"júl", "júla"}, {"august", "augusta", "aug"}, {"september", "septembra", "sep"}, {"október", "októbra", "okt"}, {"november", "novembra", "nov"}, {"december", "decembra", "dec"}},
+		Weekdays:   []string{"pondelok", "po", "utorok", "ut", "streda", "st", "štvrtok", "št", "piatok", "pi", "sobota", "so", "nedeľa", "ne"},
+		Connectors: []string{"o", "seč", "selč"},
+	},
+	{
+		Name:       "sv",
+		Months:     [12][]string{{"januari", "jan"}, {"februari", "feb"}, {"mars", "mar"}, {"april", "apr"}, {"maj"}, {"juni", "jun"}, {"juli", "jul"}, {"augusti", "aug"}, {"september", "sep", "sept"}, {"oktober", "okt"}, {"november", "nov"}, {"december", "dec"}},
+		Weekdays:   []string{"måndag", "mån", "tisdag", "tis", "onsdag", "ons", "torsdag", "tors", "fredag", "fre", "lördag", "lör", "söndag", "sön"},
+		Connectors: []string{"kl"},
+	},
+	{
+		Name:       "tr",
+		Months:     [12][]string{{"ocak", "oca"}, {"şubat", "şub"}, {"mart", "mar"}, {"nisan", "nis"}, {"mayıs", "may"}, {"haziran", "haz"}, {"temmuz", "tem"}, {"ağustos", "ağu"}, {"eylül", "eyl"}, {"ekim", "eki"}, {"kasım", "kas"}, {"aralık", "ara"}},
+		Weekdays:   []string{"pazartesi", "pzt", "salı", "sal", "çarşamba", "çar", "perşembe", "per", "cuma", "cum", "cumartesi", "cmt", "pazar", "paz"},
+		Connectors: []string{},
+	},
+	{
+		Name:       "uk",
+		Months:     [12][]string{{"січень", "січня", "січ"}, {"лютий", "лютого", "лют"}, {"березень", "березня", "бер"}, {"квітень", "квітня", "кві", "квіт"}, {"травень", "травня", "тра", "трав"}, {"червень", "червня", "чер", "черв"}, {"липень", "липня", "лип"}, {"серпень", "серпня", "сер", "серп"}, {"вересень", "вересня", "вер"}, {"жовтень", "жовтня", "жов", "жовт"}, {"листопад", "листопада", "лис"}, {"грудень", "грудня", "гру"}},
+		Weekdays:   []string{"понеділок", "пн", "вівторок", "вт", "середа", "ср", "четвер", "чт", "пʼятниця", "п'ятниця", "пт", "субота", "сб", "неділя", "нд"},
+		Connectors: []string{"р", "о"},
+	},
+}
+
+var _DateTimezones = map[string]int{
+	"gmt":  0,
+	"utc":  0,
+	"ut":   0,
+	"z":    0,
+	"wet":  0,
+	"west": 1,
+	"bst":  1,
+	"cet":  1,
+	"mez":  1,
+	"seč":  1,
+	"cest": 2,
+	"mesz": 2,
+	"selč": 2,
+	"eet":  2,
+	"oez":  2,
+	"eest": 3,
+	"oesz": 3,
+	"msk":  3,
+	"est":  -5,
+	"edt":  -4,
+	"cst":  -6,
+	"cdt":  -5,
+	"mst":  -7,
+	"mdt":  -6,
+	"pst":  -8,
+	"pdt":  -7,
+}
+
+var (
+	_DateJapanese       = regexp.MustCompile(`(\d{4})\s*年\s*(\d{1,2})\s*月\s*(\d{1,2})\s*日`)
+	_DateNumericYearEnd = regexp.MustCompile(`(?:^|[^\d.:])(\d{1,2})\s?[./-]\s?(\d{1,2})\s?[./-]\s?(\d{4})(?:[^\d]|$)`)
+	_DateNumericYearTop = regexp.MustCompile(`(?:^|[^\d])(\d{4})[./-](\d{1,2})[./-](\d{1,2})(?:[^\d]|$)`)
+	_DateNumericShort   = regexp.MustCompile(`(?:^|[^\d])(\d{1,2})/(\d{1,2})/(\d{2})(?:[^\d]|$)`)
+	_DateTime           = regexp.MustCompile(`(?:^|[^\d.:])((\d{1,2})[:.](\d{2})(?:[:.](\d{2}))?(?:\s*([ap])\.?\s?m\.?)?)(?:[^\d\pL]|$)`)
+	_DateZoneOffset     = regexp.MustCompile(`(?:^|[\s(]|gmt|utc)\s?([+-])(\d{1,2}):?(\d{2})?(?:[^\d]|$)`)
+)
+
+// ParseDate parses a date as written in the header block of a forwarded
+// email, in any of the locales and formats supported by the parser, such as
+// "25 October 2021 at 11:17:21 EEST" or "Wed, Oct 27, 2021 at 9:31 AM".
+//
+// The locale (e.g. "de") is used to resolve month names that differ between
+// languages; if empty, it is guessed from the words of the date.
+func ParseDate(value string, locale string) (time.Time, DateAmbiguity, error) {
+	s := strings.ToLower(trimString(value))
+
+	var ambiguity DateAmbiguity
+	year, month, day := 0, 0, 0
+
+	// Whether the day and month were written as numbers in an order that
+	// depends on the locale, such as "28/10/2021"
+	numericOrder := false
+
+	if match := _DateJapanese.FindStringSubmatchIndex(s); match != nil {
+		year, month, day = _Atoi(s[match[2]:match[3]]), _Atoi(s[match[4]:match[5]]), _Atoi(s[match[6]:match[7]])
+		s = s[:match[0]] + " " + s[match[1]:]
+	} else if match := _DateNumericYearTop.FindStringSubmatchIndex(s); match != nil {
+		year, month, day = _Atoi(s[match[2]:match[3]]), _Atoi(s[match[4]:match[5]]), _Atoi(s[match[6]:match[7]])
+		s = s[:match[2]] + " " + s[match[7]:]
+	} else if match := _DateNumericYearEnd.FindStringSubmatchIndex(s); match != nil {
+		day, month, year = _Atoi(s[match[2]:match[3]]), _Atoi(s[match[4]:match[5]]), _Atoi(s[match[6]:match[7]])
+		s = s[:match[2]] + " " + s[match[7]:]
+		numericOrder = true
+	}
+
+	hour, minute, second := 0, 0, 0
+	meridiem := ""
+
+	if match := _DateTime.FindStringSubmatchIndex(s); match != nil {
+		hour, minute = _Atoi(s[match[4]:match[5]]), _Atoi(s[match[6]:match[7]])
+
+		if match[8] >= 0 {
+			second = _Atoi(s[match[8]:match[9]])
+		}
+
+		if match[10] >= 0 {
+			meridiem = s[match[10]:match[11]]
+		}
+
+		// Keep what follows the time, such as the sign of "10:00-0400"
+		s = s[:match[2]] + " " + s[match[3]:]
+	} else {
+		ambiguity |= DateMissingTime
+	}
+
+	if year == 0 {
+		if match := _DateNumericShort.FindStringSubmatchIndex(s); match != nil {
+			day, month, year = _Atoi(s[match[2]:match[3]]), _Atoi(s[match[4]:match[5]]), _Atoi(s[match[6]:match[7]])
+			s = s[:match[2]] + " " + s[match[7]:]
+			numericOrder = true
+		}
+	}
+
+	zone, zoneFound := 0, false
+
+	// The sign of an offset follows a space, the time or "gmt", unlike the
+	// dashes of "27-oct-2021"
+	if match := _DateZoneOffset.FindStringSubmatchIndex(s); match != nil {
+		hours := _Atoi(s[match[4]:match[5]])
+		minutes := 0
+
+		if match[6] >= 0 {
+			minutes = _Atoi(s[match[6]:match[7]])
+		}
+
+		zone = hours*3600 + minutes*60
+		if s[match[2]:match[3]] == "-" {
+			zone = -zone
+		}
+
+		zoneFound = true
+		s = s[:match[0]] + " " + s[match[1]:]
+	}
+
+	words := _DateWords(s)
+
+	dateLocale := _FindDateLocale(words, locale)
+	numbers := []int{}
+	months := []int{}
+	weekdayMonths := []int{}
+
+	for _, word := range words {
+		word = strings.Trim(word, "-")
+
+		if n, ok := _ParseNumber(word); ok {
+			numbers = append(numbers, n)
+			continue
+		}
+
+		if offset, ok := _DateTimezones[word]; ok && !zoneFound {
+			zone = offset * 3600
+			zoneFound = true
+			continue
+		}
+
+		// Some abbreviations are both a weekday and a month ("mar" in
+		// Spanish), prefer the words that can only be a month
+		if m := dateLocale.month(word); m > 0 {
+			if _ContainsString(dateLocale.Weekdays, word) {
+				weekdayMonths = append(weekdayMonths, m)
+			} else {
+				months = append(months, m)
+			}
+		}
+	}
+
+	months = append(months, weekdayMonths...)
+
+	if year == 0 {
+		if len(months) > 0 && len(numbers) > 0 {
+			month = months[0]
+
+			for _, n := range numbers {
+				if n > 31 && year == 0 {
+					year = n
+				} else if n >= 1 && n <= 31 && day == 0 {
+					day = n
+				}
+			}
+
+			if year == 0 && len(numbers) > 1 {
+				year = numbers[len(numbers)-1]
+			}
+		} else if len(numbers) >= 3 {
+			day, month, year = numbers[0], numbers[1], numbers[2]
+			numericOrder = true
+		}
+	}
+
+	if year > 0 && year < 100 {
+		if year < 70 {
+			year += 2000
+		} else {
+			year += 1900
+		}
+	}
+
+	if numericOrder {
+		if day <= 12 && month <= 12 && day != month {
+			ambiguity |= DateAmbiguousOrder
+
+			// 12-hour clocks are a good hint for the US month-first order
+			if len(meridiem) > 0 {
+				day, month = month, day
+			}
+		} else if month > 12 && day <= 12 {
+			day, month = month, day
+		}
+	}
+
+	if year == 0 || month < 1 || month > 12 || day < 1 || day > 31 {
+		return time.Time{}, ambiguity, ErrInvalidDate
+	}
+
+	if meridiem == "p" && hour < 12 {
+		hour += 12
+	} else if meridiem == "a" && hour == 12 {
+		hour = 0
+	}
+
+	if hour > 23 || minute > 59 || second > 60 {
+		return time.Time{}, ambiguity, ErrInvalidDate
+	}
+
+	location := time.UTC
+
+	if zoneFound {
+		location = time.FixedZone("", zone)
+	} else {
+		ambiguity |= DateMissingTimezone
+	}
+
+	// time.Date normalizes days past the end of the month, such as 31
+	// February into 3 March
+	if time.Date(year, time.Month(month), day, 0, 0, 0, 0, location).Day() != day {
+		return time.Time{}, ambiguity, ErrInvalidDate
+	}
+
+	return time.Date(year, time.Month(month), day, hour, minute, second, 0, location), ambiguity, nil
+}
+
+func _DateWords(s string) []string {
+	words := []string{}
+
+	for _, word := range strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' && r != '\'' && r != 'ʼ'
+	}) {
+		// "27-oct-2021", unlike hyphenated words
+		if strings.ContainsAny(word, "0123456789") {
+			words = append(words, strings.FieldsFunc(word, func(r rune) bool { return r == '-' })...)
+		} else {
+			words = append(words, word)
+		}
+	}
+
+	return words
+}
+
+func (locale _DateLocale) month(word string) int {
+	for i, names := range locale.Months {
+		if _ContainsString(names, word) {
+			return i + 1
+		}
+	}
+
+	return 0
+}
+
+func (locale _DateLocale) score(words []string) int {
+	score := 0
+
+	for _, word := range words {
+		if locale.month(word) > 0 || _ContainsString(locale.Weekdays, word) || _ContainsString(locale.Connectors, word) {
+			score++
+		}
+	}
+
+	return score
+}
+
+func _FindDateLocale(words []string, name string) _DateLocale {
+	name = strings.ToLower(name)
+	if i := strings.IndexAny(name, "-_"); i >= 0 {
+		name = name[:i]
+	}
+
+	best := _DateLocales[0]
+	bestScore := -1
+
+	// The given locale is only used if the date is written in it, as some
+	// clients always write dates in English
+	for _, locale := range _DateLocales {
+		if locale.Name == name && locale.score(words) > 0 {
+			return locale
+		}
+
+		if score := locale.score(words); score > bestScore {
+			best = locale
+			bestScore = score
+		}
+	}
+
+	return best
+}
+
+func _Atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+
+	return n
+}
+
+// _ParseNumber is strconv.Atoi without the error, which is allocated, for
+// the words of a date that are not numbers
+func _ParseNumber(word string) (int, bool) {
+	for i := 0; i < len(word); i++ {
+		if word[i] < '0' || word[i] > '9' {
+			return 0, false
+		}
+	}
+
+	n, err := strconv.Atoi(word)
+
+	return n, err == nil
+}
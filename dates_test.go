@@ -0,0 +1,287 @@
+package emailforwardparser
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseDate(t *testing.T) {
+	for _, entry := range []struct {
+		Value     string
+		Locale    string
+		Expected  string
+		Ambiguity DateAmbiguity
+	}{
+		{"25 October 2021 at 11:17:21 EEST", "", "2021-10-25T11:17:21+03:00", 0},
+		{"Wed, Oct 27, 2021 at 9:31 AM", "", "2021-10-27T09:31:00Z", DateMissingTimezone},
+		{"26. listopada 2021. u 14:25:08 EEST", "", "2021-10-26T14:25:08+03:00", 0},
+		{"26 listopada 2021 o 14:25:08 EEST", "", "2021-11-26T14:25:08+03:00", 0},
+		{"26. listopada 2021 14:25:08 EEST", "hr", "2021-10-26T14:25:08+03:00", 0},
+		{"2021. okt. 27., Sze, 9:31", "", "2021-10-27T09:31:00Z", DateMissingTimezone},
+		{"2022年9月19日月曜日 17:55:44 -0400", "", "2022-09-19T17:55:44-04:00", 0},
+		{"maanantai 19. syyskuuta 2022 klo 17.55.44 -0400", "", "2022-09-19T17:55:44-04:00", 0},
+		{"tiistaina 2. marraskuuta 2021 klo 09.26.50 UTC+1", "", "2021-11-02T09:26:50+01:00", 0},
+		{"st 27. 10. 2021 v 9:31", "", "2021-10-27T09:31:00Z", DateMissingTimezone},
+		{"quarta, 27/10/2021 à(s) 09:31", "", "2021-10-27T09:31:00Z", DateMissingTimezone},
+		{"28/10/21, 12:46", "", "2021-10-28T12:46:00Z", DateMissingTimezone},
+		{"02/10/2023 4:52 PM EST", "", "2023-02-10T16:52:00-05:00", DateAmbiguousOrder},
+		{"02/10/2023 16:52", "", "2023-10-02T16:52:00Z", DateAmbiguousOrder | DateMissingTimezone},
+		{"2 Kasım 2021 Salı 09:26:50 GMT+1", "", "2021-11-02T09:26:50+01:00", 0},
+		{"вівторок, 2 листопада 2021 р., 09:26:50 GMT+1", "", "2021-11-02T09:26:50+01:00", 0},
+		{"July 1, 2022", "", "2022-07-01T00:00:00Z", DateMissingTime | DateMissingTimezone},
+		{"27-Oct-2021 10:00", "", "2021-10-27T10:00:00Z", DateMissingTimezone},
+		{"27-Oct-2021", "", "2021-10-27T00:00:00Z", DateMissingTime | DateMissingTimezone},
+		{"Wed, 27-Oct-2021 10:00 (GMT+02:00)", "", "2021-10-27T10:00:00+02:00", 0},
+		{"27 Oct 2021 10:00-0400", "", "2021-10-27T10:00:00-04:00", 0},
+	} {
+		date, ambiguity, err := ParseDate(entry.Value, entry.Locale)
+		if err != nil {
+			t.Error(entry.Value, err)
+			continue
+		}
+
+		if date.Format(time.RFC3339) != entry.Expected {
+			t.Error(entry.Value, "unexpected date", date.Format(time.RFC3339))
+		}
+
+		if ambiguity != entry.Ambiguity {
+			t.Error(entry.Value, "unexpected ambiguity", ambiguity)
+		}
+	}
+
+	for _, value := range []string{"", "yesterday", "32 October 2021", "31 February 2021 10:00", "29/02/2021"} {
+		if _, _, err := ParseDate(value, ""); err == nil {
+			t.Error(value, "expected an invalid date error")
+		}
+	}
+}
+
+// The dates of the fixtures, in the time zone they are written in, or UTC
+var _FixtureDates = map[string]string{
+	"apple_mail_cs_body":                    "2021-10-26T14:25:08+03:00",
+	"apple_mail_da_body":                    "2021-10-26T14:25:08+03:00",
+	"apple_mail_de_body":                    "2021-10-26T14:25:08+03:00",
+	"apple_mail_de_body_variant_16":         "2021-10-26T14:25:08+03:00",
+	"apple_mail_en_body":                    "2021-10-25T11:17:21+03:00",
+	"apple_mail_en_body_variant_1":          "2021-10-25T11:17:21+03:00",
+	"apple_mail_en_body_variant_13":         "2022-08-17T09:06:24+02:00",
+	"apple_mail_en_body_variant_2":          "2021-10-25T11:17:21+03:00",
+	"apple_mail_en_body_variant_3":          "2021-10-25T11:17:21+03:00",
+	"apple_mail_en_body_variant_5":          "2021-10-25T11:17:21+03:00",
+	"apple_mail_en_body_variant_6":          "2021-07-16T19:24:14+02:00",
+	"apple_mail_en_body_variant_7":          "2021-07-16T19:24:14+02:00",
+	"apple_mail_es_body":                    "2021-10-26T14:25:08+03:00",
+	"apple_mail_fi_body":                    "2021-10-26T14:25:08+03:00",
+	"apple_mail_fr_body":                    "2021-10-26T14:25:08+03:00",
+	"apple_mail_hr_body":                    "2021-10-26T14:25:08+03:00",
+	"apple_mail_hu_body":                    "2021-10-26T14:25:08+03:00",
+	"apple_mail_it_body":                    "2021-10-26T14:25:08+03:00",
+	"apple_mail_nl_body":                    "2021-10-26T14:25:08+03:00",
+	"apple_mail_no_body":                    "2021-10-26T14:25:08+03:00",
+	"apple_mail_pl_body":                    "2021-10-26T14:25:08+03:00",
+	"apple_mail_pt_body":                    "2021-10-26T14:25:08+03:00",
+	"apple_mail_pt_br_body":                 "2021-10-26T14:25:08+03:00",
+	"apple_mail_ro_body":                    "2021-10-26T14:25:08+03:00",
+	"apple_mail_ru_body":                    "2021-10-26T14:25:08+03:00",
+	"apple_mail_sk_body":                    "2021-10-26T14:25:08+03:00",
+	"apple_mail_sv_body":                    "2021-10-26T14:25:08+03:00",
+	"apple_mail_tr_body":                    "2021-10-26T14:25:08+03:00",
+	"apple_mail_uk_body":                    "2021-10-26T14:25:08+03:00",
+	"gmail_cs_body":                         "2021-10-27T09:31:00Z",
+	"gmail_da_body":                         "2021-10-27T09:31:00Z",
+	"gmail_de_body":                         "2021-10-27T09:31:00Z",
+	"gmail_en_body":                         "2021-10-27T09:31:00Z",
+	"gmail_en_body_variant_1":               "2021-10-27T09:31:00Z",
+	"gmail_en_body_variant_14":              "2023-04-06T16:17:00Z",
+	"gmail_en_body_variant_15":              "2023-04-06T16:17:00Z",
+	"gmail_en_body_variant_2":               "2021-10-27T09:31:00Z",
+	"gmail_en_body_variant_3":               "2021-10-27T09:31:00Z",
+	"gmail_es_body":                         "2021-10-27T09:31:00Z",
+	"gmail_et_body":                         "2021-10-27T09:31:00Z",
+	"gmail_fi_body":                         "2021-10-27T09:31:00Z",
+	"gmail_fr_body":                         "2021-10-27T09:31:00Z",
+	"gmail_hr_body":                         "2021-10-27T09:31:00Z",
+	"gmail_hu_body":                         "2021-10-27T09:31:00Z",
+	"gmail_it_body":                         "2021-10-27T09:31:00Z",
+	"gmail_nl_body":                         "2021-10-27T09:31:00Z",
+	"gmail_no_body":                         "2021-10-27T09:31:00Z",
+	"gmail_pl_body":                         "2021-10-27T09:31:00Z",
+	"gmail_pt_body":                         "2021-10-27T09:31:00Z",
+	"gmail_pt_br_body":                      "2021-10-27T09:31:00Z",
+	"gmail_ro_body":                         "2021-10-27T09:31:00Z",
+	"gmail_ru_body":                         "2021-10-27T09:31:00Z",
+	"gmail_sk_body":                         "2021-10-27T09:31:00Z",
+	"gmail_sv_body":                         "2021-10-27T09:31:00Z",
+	"gmail_tr_body":                         "2021-10-27T09:31:00Z",
+	"gmail_uk_body":                         "2021-10-27T09:31:00Z",
+	"hubspot_de_body":                       "2022-09-19T17:55:44-04:00",
+	"hubspot_en_body":                       "2022-09-19T17:55:44-04:00",
+	"hubspot_en_body_variant_1":             "2022-09-19T17:55:44-04:00",
+	"hubspot_en_body_variant_2":             "2022-09-19T17:55:44-04:00",
+	"hubspot_es_body":                       "2022-09-19T17:55:44-04:00",
+	"hubspot_fi_body":                       "2022-09-19T17:55:44-04:00",
+	"hubspot_fr_body":                       "2022-09-19T15:20:05+02:00",
+	"hubspot_it_body":                       "2022-09-19T17:55:44-04:00",
+	"hubspot_ja_body":                       "2022-09-19T17:55:44-04:00",
+	"hubspot_nl_body":                       "2022-09-19T17:55:44-04:00",
+	"hubspot_pl_body":                       "2022-09-19T17:55:44-04:00",
+	"hubspot_pt_br_body":                    "2022-09-19T17:55:44-04:00",
+	"hubspot_sv_body":                       "2022-09-19T17:55:44-04:00",
+	"ionos_one_and_one_en_body":             "2023-02-10T16:52:00-05:00",
+	"ionos_one_and_one_en_body_variant_2":   "2023-02-10T16:52:00-05:00",
+	"missive_en_body":                       "2022-07-19T15:09:00Z",
+	"missive_en_body_variant_1":             "2022-07-19T15:36:00Z",
+	"missive_en_body_variant_2":             "2022-07-19T15:36:00Z",
+	"missive_en_body_variant_3":             "2022-07-01T17:03:00Z",
+	"new_outlook_2019_cs_body":              "2021-10-28T12:06:00Z",
+	"new_outlook_2019_da_body":              "2021-10-28T12:06:00Z",
+	"new_outlook_2019_de_body":              "2021-10-28T12:46:00Z",
+	"new_outlook_2019_en_body":              "2021-10-28T12:46:00Z",
+	"new_outlook_2019_en_body_variant_1":    "2021-10-28T12:46:00Z",
+	"new_outlook_2019_en_body_variant_14":   "2023-04-06T16:17:00Z",
+	"new_outlook_2019_en_body_variant_14_1": "2023-04-06T16:17:00Z",
+	"new_outlook_2019_en_body_variant_15":   "2023-04-06T16:17:00Z",
+	"new_outlook_2019_en_body_variant_2":    "2021-10-28T12:46:00Z",
+	"new_outlook_2019_en_body_variant_3":    "2021-10-28T12:46:00Z",
+	"new_outlook_2019_en_body_variant_4":    "2021-10-28T12:46:00Z",
+	"new_outlook_2019_es_body":              "2021-10-28T12:46:00Z",
+	"new_outlook_2019_fi_body":              "2021-10-28T12:06:00Z",
+	"new_outlook_2019_fr_body":              "2021-10-28T12:06:00Z",
+	"new_outlook_2019_hu_body":              "2021-10-28T12:46:00Z",
+	"new_outlook_2019_it_body":              "2021-10-28T12:46:00Z",
+	"new_outlook_2019_nl_body":              "2021-10-28T12:06:00Z",
+	"new_outlook_2019_no_body":              "2021-10-28T12:46:00Z",
+	"new_outlook_2019_pl_body":              "2021-10-28T12:46:00Z",
+	"new_outlook_2019_pt_body":              "2021-10-28T12:46:00Z",
+	"new_outlook_2019_pt_br_body":           "2021-10-28T12:46:00Z",
+	"new_outlook_2019_ru_body":              "2021-10-28T12:46:00Z",
+	"new_outlook_2019_sk_body":              "2021-10-28T12:46:00Z",
+	"new_outlook_2019_sv_body":              "2021-10-28T12:46:00Z",
+	"new_outlook_2019_tr_body":              "2021-10-28T12:46:00Z",
+	"outlook_2013_en_body":                  "2021-10-25T11:17:00Z",
+	"outlook_2019_cz_body":                  "2021-10-28T12:46:00Z",
+	"outlook_2019_da_body":                  "2021-10-28T12:46:00Z",
+	"outlook_2019_de_body":                  "2021-10-28T12:46:00Z",
+	"outlook_2019_en_body":                  "2021-10-28T12:46:00Z",
+	"outlook_2019_en_body_variant_2":        "2021-10-28T12:46:00Z",
+	"outlook_2019_en_body_variant_4":        "2021-10-28T12:46:00Z",
+	"outlook_2019_es_body":                  "2021-10-28T12:46:00Z",
+	"outlook_2019_fi_body":                  "2021-10-28T12:46:00Z",
+	"outlook_2019_fr_body":                  "2021-10-28T12:46:00Z",
+	"outlook_2019_hu_body":                  "2021-10-28T12:46:00Z",
+	"outlook_2019_it_body":                  "2021-10-28T12:46:00Z",
+	"outlook_2019_nl_body":                  "2021-10-28T12:46:00Z",
+	"outlook_2019_no_body":                  "2021-10-28T12:46:00Z",
+	"outlook_2019_pl_body":                  "2021-10-28T12:46:00Z",
+	"outlook_2019_pt_body":                  "2021-10-28T12:46:00Z",
+	"outlook_2019_ru_body":                  "2021-10-28T12:46:00Z",
+	"outlook_2019_sk_body":                  "2021-10-28T12:46:00Z",
+	"outlook_2019_sv_body":                  "2021-10-28T12:46:00Z",
+	"outlook_2019_tr_body":                  "2021-10-28T12:46:00Z",
+	"outlook_live_body":                     "2021-10-27T15:14:00Z",
+	"outlook_live_en_body_variant_1":        "2021-10-27T15:14:00Z",
+	"outlook_live_en_body_variant_10":       "2021-10-27T15:14:00Z",
+	"outlook_live_en_body_variant_11":       "2021-10-27T15:14:00Z",
+	"outlook_live_en_body_variant_14":       "2023-04-06T16:17:00Z",
+	"outlook_live_en_body_variant_15":       "2023-04-06T16:17:00Z",
+	"outlook_live_en_body_variant_2":        "2021-10-27T15:14:00Z",
+	"outlook_live_en_body_variant_3":        "2021-10-27T15:14:00Z",
+	"outlook_live_en_body_variant_4":        "2021-10-27T15:14:00Z",
+	"outlook_live_en_body_variant_8":        "2021-10-27T15:14:00Z",
+	"outlook_live_en_body_variant_9":        "2021-10-27T15:14:00Z",
+	"thunderbird_cs_body":                   "2021-11-03T15:51:30+01:00",
+	"thunderbird_da_body":                   "2021-11-03T15:51:30+01:00",
+	"thunderbird_de_body":                   "2021-11-03T15:51:30+01:00",
+	"thunderbird_en_body":                   "2021-11-03T15:51:30+01:00",
+	"thunderbird_en_body_variant_1":         "2021-11-03T15:51:30+01:00",
+	"thunderbird_en_body_variant_14":        "2023-04-06T16:17:00Z",
+	"thunderbird_en_body_variant_15":        "2023-04-06T16:17:00Z",
+	"thunderbird_en_body_variant_16":        "2021-11-03T15:51:30+01:00",
+	"thunderbird_en_body_variant_2":         "2021-11-03T15:51:30+01:00",
+	"thunderbird_en_body_variant_3":         "2021-11-03T15:51:30+01:00",
+	"thunderbird_es_body":                   "2021-11-03T15:51:30+01:00",
+	"thunderbird_fi_body":                   "2021-11-03T15:51:30+01:00",
+	"thunderbird_fr_body":                   "2021-11-03T15:51:30+01:00",
+	"thunderbird_hr_body":                   "2021-11-03T15:51:30+01:00",
+	"thunderbird_hu_body":                   "2021-11-03T15:51:30+01:00",
+	"thunderbird_it_body":                   "2021-11-03T15:51:30+01:00",
+	"thunderbird_nl_body":                   "2021-11-03T15:51:30+01:00",
+	"thunderbird_no_body":                   "2021-11-03T15:51:30+01:00",
+	"thunderbird_pl_body":                   "2021-11-03T15:51:30+01:00",
+	"thunderbird_pt_body":                   "2021-11-03T15:51:30+01:00",
+	"thunderbird_pt_br_body":                "2021-11-03T15:51:30+01:00",
+	"thunderbird_ro_body":                   "2021-11-03T15:51:30+01:00",
+	"thunderbird_ru_body":                   "2021-11-03T15:51:30+01:00",
+	"thunderbird_sk_body":                   "2021-11-03T15:51:30+01:00",
+	"thunderbird_sv_body":                   "2021-11-03T15:51:30+01:00",
+	"thunderbird_tr_body":                   "2021-11-03T15:51:30+01:00",
+	"thunderbird_uk_body":                   "2021-11-03T15:51:30+01:00",
+	"unknown_en_body_variant_12":            "2022-07-23T07:53:00Z",
+	"yahoo_cs_body":                         "2021-11-02T09:26:50+01:00",
+	"yahoo_da_body":                         "2021-11-02T09:26:50+01:00",
+	"yahoo_de_body":                         "2021-11-02T09:26:50+01:00",
+	"yahoo_en_body":                         "2021-11-02T09:26:50+01:00",
+	"yahoo_en_body_variant_1":               "2021-11-02T09:26:50+01:00",
+	"yahoo_en_body_variant_2":               "2021-11-02T09:26:50+01:00",
+	"yahoo_en_body_variant_3":               "2021-11-02T09:26:50+01:00",
+	"yahoo_es_body":                         "2021-11-02T09:26:50+01:00",
+	"yahoo_fi_body":                         "2021-11-02T09:26:50+01:00",
+	"yahoo_fr_body":                         "2021-11-02T09:26:50+01:00",
+	"yahoo_hu_body":                         "2021-11-02T09:26:50+01:00",
+	"yahoo_it_body":                         "2021-11-02T09:26:50+01:00",
+	"yahoo_nl_body":                         "2021-11-02T09:26:50+01:00",
+	"yahoo_no_body":                         "2021-11-02T09:26:50+01:00",
+	"yahoo_pl_body":                         "2021-11-02T09:26:50+01:00",
+	"yahoo_pt_body":                         "2021-11-02T09:26:50+01:00",
+	"yahoo_pt_br_body":                      "2021-11-02T09:26:50+01:00",
+	"yahoo_ro_body":                         "2021-11-02T09:26:50+01:00",
+	"yahoo_ru_body":                         "2021-11-02T09:26:50+01:00",
+	"yahoo_sk_body":                         "2021-11-02T09:26:50+01:00",
+	"yahoo_sv_body":                         "2021-11-02T09:26:50+01:00",
+	"yahoo_tr_body":                         "2021-11-02T09:26:50+01:00",
+	"yahoo_uk_body":                         "2021-11-02T09:26:50+01:00",
+}
+
+func TestParseDateFixtures(t *testing.T) {
+	entries, err := os.ReadDir("./fixtures")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dated := 0
+
+	for _, entry := range entries {
+		name := strings.TrimSuffix(entry.Name(), ".txt")
+
+		if name == entry.Name() || strings.Contains(name, "_subject") {
+			continue
+		}
+
+		email, _ := _Read(name, "")
+		result := Read(email, "Fwd: "+_TestSubject)
+
+		expected, ok := _FixtureDates[name]
+
+		if len(result.Email.Date) == 0 {
+			if ok {
+				t.Error(name, "no date was read, expected", expected)
+			}
+
+			continue
+		}
+
+		dated++
+
+		if !ok {
+			t.Error(name, "no expected date for", result.Email.Date)
+		} else if date := result.Email.DateTime.Format(time.RFC3339); date != expected {
+			t.Error(name, "unexpected date", result.Email.Date, date, "expected", expected)
+		}
+	}
+
+	if dated != len(_FixtureDates) {
+		t.Error("unexpected number of dated fixtures", dated, len(_FixtureDates))
+	}
+}
@@ -1,47 +1,86 @@
 package emailforwardparser
 
 import (
+	"net/mail"
 	"strings"
+	"time"
+	"unicode"
 
 	regexp "github.com/wasilibs/go-re2"
 )
 
-func _ParseSubject(subject string) string {
-	match, _ := _LoopRegexesMatch(_Subject, subject, true)
+func (parser *Parser) _ParseSubject(subject string) (string, *regexp.Regexp) {
+	parser.trace._SetStage("Subject")
+
+	match, pattern := parser._MatchPatterns(parser.patterns.Subject, subject)
 
 	if len(match) > 0 {
-		return trimString(match[1])
+		return trimString(match[1]), pattern
 	}
 
-	return ""
+	return "", nil
 }
 
 type _ParseBodyResult struct {
 	Body    string
 	Message string
 	Email   string
+	// The signature split off Message, for WithSignatures
+	Signature string
+
+	Separator *regexp.Regexp
+	// The OriginalFrom pattern the body was split on, if there is no
+	// separator
+	FromSplit *regexp.Regexp
+	// Whether a separator or "From" line was found with nothing after it,
+	// as in a truncated body
+	Truncated bool
+
+	// The positions of the message, separator and email in Body, and the
+	// offsets of the bytes of Body and Email in the input when spans are
+	// needed
+	MessageLoc   []int
+	SignatureLoc []int
+	SeparatorLoc []int
+	EmailLoc     []int
+	Offsets      []int
+	EmailOffsets []int
 }
 
-func _ParseBody(body string, forwarded bool) _ParseBodyResult {
-	body = _CarriageReturn.ReplaceAllString(body, "\n")
-	body = _ByteOrderMark.ReplaceAllString(body, "")
-	body = _TrailingNonBreakingSpace.ReplaceAllString(body, "")
-	body = _NonBreakingSpace.ReplaceAllString(body, " ")
+func (parser *Parser) _ParseBody(body string, forwarded bool, offsets []int) _ParseBodyResult {
+	body, offsets = _ReplaceAllMapped(_CarriageReturn, body, "\n", offsets)
+	body, offsets = _ReplaceAllMapped(_ByteOrderMark, body, "", offsets)
+	body, offsets = _ReplaceAllMapped(_TrailingNonBreakingSpace, body, "", offsets)
+	body, offsets = _ReplaceAllMapped(_NonBreakingSpace, body, " ", offsets)
+
+	parser.trace._SetStage("Body")
 
-	match := _LoopRegexesSplit(_Separator, body, true)
+	match, separator := parser._SplitPatterns(parser.patterns.Separator, body)
 
 	if len(match) > 2 {
 		email := reconciliateSplitMatch(match, 3, []int{2}, nil)
+		emailStart := len(match[0]) + len(match[1])
 
 		return _ParseBodyResult{
 			Body:    body,
 			Message: trimString(match[0]),
 			Email:   trimString(email),
-		}
+
+			Separator: separator,
+
+			MessageLoc:   _TrimmedLoc(body, 0, len(match[0])),
+			SeparatorLoc: _TrimmedLoc(body, len(match[0]), emailStart),
+			EmailLoc:     _TrimmedLoc(body, emailStart, len(body)),
+			Offsets:      offsets,
+		}._WithEmailOffsets()
 	}
 
+	parser._Reject(separator, "nothing follows the separator")
+
+	truncated := separator != nil
+
 	if forwarded {
-		match = _LoopRegexesSplit(_OriginalFrom, body, true)
+		match, fromSplit := parser._SplitPatterns(parser.patterns.OriginalFrom, body)
 
 		if len(match) > 3 {
 			email := reconciliateSplitMatch(match, 4, []int{1, 3}, func(i int) bool { return i%3 == 2 })
@@ -50,232 +89,429 @@ func _ParseBody(body string, forwarded bool) _ParseBodyResult {
 				Body:    body,
 				Message: trimString(match[0]),
 				Email:   trimString(email),
-			}
+
+				FromSplit: fromSplit,
+
+				MessageLoc: _TrimmedLoc(body, 0, len(match[0])),
+				EmailLoc:   _TrimmedLoc(body, len(match[0]), len(body)),
+				Offsets:    offsets,
+			}._WithEmailOffsets()
 		}
+
+		parser._Reject(fromSplit, "nothing follows the header")
+
+		truncated = truncated || fromSplit != nil
+	}
+
+	return _ParseBodyResult{Truncated: truncated}
+}
+
+func (result _ParseBodyResult) _WithEmailOffsets() _ParseBodyResult {
+	if result.Offsets != nil {
+		result.EmailOffsets = result.Offsets[result.EmailLoc[0] : result.EmailLoc[1]+1]
 	}
 
-	return _ParseBodyResult{}
+	return result
 }
 
-func _ParseOriginalBody(text string) string {
+func (parser *Parser) _ParseOriginalBody(text string) (string, *regexp.Regexp) {
 	regexeses := [][]*regexp.Regexp{
-		_OriginalSubject,
-		_OriginalCC,
-		_OriginalTo,
-		_OriginalReplyTo,
+		parser.patterns.OriginalSubject,
+		parser.patterns.OriginalCC,
+		parser.patterns.OriginalTo,
+		parser.patterns.OriginalReplyTo,
+		parser.patterns.OriginalBCC,
+		parser.patterns.OriginalMessageID,
+		parser.patterns.OriginalInReplyTo,
+		parser.patterns.OriginalReferences,
 	}
 
+	// Use the header line closest to the top that ends the header block, so
+	// headers of a nested forward are not mistaken for the end of this one
+	var bodyMatch []string
+	var bodyPattern *regexp.Regexp
+	bodyIndex := -1
+
+	parser.trace._SetStage("OriginalBody")
+
 	for _, regexes := range regexeses {
-		match := _LoopRegexesSplit(regexes, text, true)
+		match, pattern := parser._SplitPatterns(regexes, text)
 
-		if len(match) > 2 && strings.HasPrefix(match[3], "\n\n") {
-			body := reconciliateSplitMatch(match, 4, []int{3}, func(i int) bool { return i%3 == 2 })
+		if len(match) > 3 && strings.HasPrefix(match[3], "\n\n") {
+			index := len(match[0]) + len(match[1])
 
-			return trimString(body)
+			if bodyIndex < 0 || index < bodyIndex {
+				parser._Reject(bodyPattern, "another header ending the header block is closer to the top")
+
+				bodyMatch = match
+				bodyPattern = pattern
+				bodyIndex = index
+			} else {
+				parser._Reject(pattern, "another header ending the header block is closer to the top")
+			}
+		} else if len(match) > 3 {
+			parser._Reject(pattern, "match[3] does not start with \\n\\n: no empty line follows the header")
+		} else {
+			parser._Reject(pattern, "nothing follows the header")
 		}
 	}
 
-	match := _LoopRegexesSplit(append(_OriginalSubject, _OriginalSubjectLax...), text, true)
+	if bodyMatch != nil {
+		body := reconciliateSplitMatch(bodyMatch, 4, []int{3}, func(i int) bool { return i%3 == 2 })
+
+		return trimString(body), bodyPattern
+	}
+
+	match, pattern := parser._SplitPatterns(parser.patterns.OriginalSubjectAndLax, text)
 
 	if len(match) > 3 {
 		body := reconciliateSplitMatch(match, 4, []int{3}, func(i int) bool { return i%3 == 2 })
 
-		return trimString(body)
+		return trimString(body), pattern
 	}
 
-	return text
+	parser._Reject(pattern, "nothing follows the header")
+
+	return text, nil
 }
 
 type _ParseOriginalEmailResult struct {
 	Body string
 
-	From _MailboxResult
-	To   []_MailboxResult
-	CC   []_MailboxResult
+	From    Mailbox
+	To      []Mailbox
+	CC      []Mailbox
+	BCC     []Mailbox
+	ReplyTo []Mailbox
 
 	Subject string
 	Date    string
+
+	MessageID  string
+	InReplyTo  string
+	References []string
+
+	// The header patterns that matched, the SeparatorWithInformation
+	// pattern that matched if the sender or date were not found in a
+	// header, and the pattern of the header line that ends the header block
+	Headers     _HeaderPatterns
+	Information *regexp.Regexp
+	BodyPattern *regexp.Regexp
+
+	// The unquoted header block and body, the position of the body in it,
+	// and the offsets of its bytes in the input when spans are needed
+	Text    string
+	BodyLoc []int
+	Offsets []int
 }
 
-func _ParseOriginalEmail(text string, body string) _ParseOriginalEmailResult {
-	text = _ByteOrderMark.ReplaceAllString(text, "")
-	text = _QuoteLineBreak.ReplaceAllString(text, "")
-	text = _Quote.ReplaceAllString(text, "")
-	text = _FourSpaces.ReplaceAllString(text, "")
+type _HeaderPatterns struct {
+	From       *regexp.Regexp
+	To         *regexp.Regexp
+	CC         *regexp.Regexp
+	BCC        *regexp.Regexp
+	ReplyTo    *regexp.Regexp
+	Subject    *regexp.Regexp
+	Date       *regexp.Regexp
+	MessageID  *regexp.Regexp
+	InReplyTo  *regexp.Regexp
+	References *regexp.Regexp
+}
 
-	return _ParseOriginalEmailResult{
-		Body: _ParseOriginalBody(text),
+func (parser *Parser) _ParseOriginalEmail(text string, body string, offsets []int) _ParseOriginalEmailResult {
+	text, offsets = _ReplaceAllMapped(_ByteOrderMark, text, "", offsets)
+	text, offsets = _ReplaceAllMapped(_QuoteLineBreak, text, "", offsets)
+	text, offsets = _ReplaceAllMapped(_Quote, text, "", offsets)
+	text, offsets = _ReplaceAllMapped(_FourSpaces, text, "", offsets)
 
-		From: _ParseOriginalFrom(text, body),
-		To:   _ParseOriginalTo(text),
-		CC:   _ParseOriginalCC(text),
+	result := _ParseOriginalEmailResult{
+		Text:    text,
+		Offsets: offsets,
+	}
 
-		Subject: _ParseOriginalSubject(text),
-		Date:    _ParseOriginalDate(text, body),
+	result.Body, result.BodyPattern = parser._ParseOriginalBody(text)
+
+	result.BodyLoc = _SuffixLoc(text, result.Body)
+
+	parser.trace._SetStage("OriginalFrom")
+	result.From, result.Headers.From = parser._ParseOriginalFrom(text, body)
+	parser.trace._SetStage("OriginalTo")
+	result.To, result.Headers.To = parser._ParseOriginalTo(text)
+	parser.trace._SetStage("OriginalCC")
+	result.CC, result.Headers.CC = parser._ParseOriginalCC(text)
+	parser.trace._SetStage("OriginalBCC")
+	result.BCC, result.Headers.BCC = parser._ParseMailbox(parser.patterns.OriginalBCC, text)
+	parser.trace._SetStage("OriginalReplyTo")
+	result.ReplyTo, result.Headers.ReplyTo = parser._ParseMailbox(parser.patterns.OriginalReplyTo, text)
+
+	parser.trace._SetStage("OriginalSubject")
+	result.Subject, result.Headers.Subject = parser._ParseOriginalSubject(text)
+	parser.trace._SetStage("OriginalDate")
+	result.Date, result.Headers.Date = parser._ParseOriginalDate(text, body)
+
+	parser.trace._SetStage("OriginalMessageID")
+	messageID, messageIDPattern := parser._ParseOriginalHeader(parser.patterns.OriginalMessageID, text)
+	parser.trace._SetStage("OriginalInReplyTo")
+	inReplyTo, inReplyToPattern := parser._ParseOriginalHeader(parser.patterns.OriginalInReplyTo, text)
+	parser.trace._SetStage("OriginalReferences")
+	references, referencesPattern := parser._ParseOriginalHeader(parser.patterns.OriginalReferences, text)
+
+	result.MessageID, result.Headers.MessageID = _ParseMessageID(messageID), messageIDPattern
+	result.InReplyTo, result.Headers.InReplyTo = _ParseMessageID(inReplyTo), inReplyToPattern
+	result.References, result.Headers.References = _ParseMessageIDs(references), referencesPattern
+
+	if result.Headers.From == nil || result.Headers.Date == nil {
+		if match, pattern := parser.patterns._Match(parser.patterns.SeparatorWithInformation, body); len(match) == 4 {
+			result.Information = pattern
+		}
 	}
+
+	return result
 }
 
-func _ParseOriginalFrom(text string, body string) _MailboxResult {
+func (parser *Parser) _ParseOriginalFrom(text string, body string) (Mailbox, *regexp.Regexp) {
 	var name string
 	var address string
 
-	authors := _ParseMailbox(_OriginalFrom, text)
+	authors, pattern := parser._ParseMailbox(parser.patterns.OriginalFrom, text)
 
 	if len(authors) > 0 {
 		author := authors[0]
 
 		if len(author.Name) > 0 || len(author.Address) > 0 {
-			return author
+			return author, pattern
 		}
+
+		parser._Reject(pattern, "no sender in the header")
 	}
 
-	match, pattern := _LoopRegexesMatch(_SeparatorWithInformation, body, true)
+	match, pattern := parser._MatchPatterns(parser.patterns.SeparatorWithInformation, body)
 
 	if len(match) == 4 {
 		namedMatches := findNamedMatches(pattern, body)
 
-		return _PrepareMailbox(namedMatches["from_name"], namedMatches["from_address"])
+		return parser._PrepareMailbox(namedMatches["from_name"], namedMatches["from_address"]), nil
 	}
 
-	match, _ = _LoopRegexesMatch(_OriginalFromLax, text, true)
+	parser._Reject(pattern, "the separator does not hold the three named groups")
+
+	match, pattern = parser._MatchPatterns(parser.patterns.OriginalFromLax, text)
 
 	if len(match) > 1 {
 		name = match[2]
 		address = match[3]
 
-		return _PrepareMailbox(name, address)
+		return parser._PrepareMailbox(name, address), pattern
 	}
 
-	return _PrepareMailbox("", "")
+	return parser._PrepareMailbox("", ""), nil
 }
 
-func _ParseOriginalTo(text string) []_MailboxResult {
-	recipients := _ParseMailbox(_OriginalTo, text)
+func (parser *Parser) _ParseOriginalTo(text string) ([]Mailbox, *regexp.Regexp) {
+	recipients, pattern := parser._ParseMailbox(parser.patterns.OriginalTo, text)
 
 	if len(recipients) > 0 {
-		return recipients
+		return recipients, pattern
 	}
 
-	text = _LoopRegexesReplace(_OriginalSubjectLax, text)
-	text = _LoopRegexesReplace(_OriginalDateLax, text)
-	text = _LoopRegexesReplace(_OriginalCCLax, text)
+	text = parser._ReplacePatterns(parser.patterns.OriginalSubjectLax, text)
+	text = parser._ReplacePatterns(parser.patterns.OriginalDateLax, text)
+	text = parser._ReplacePatterns(parser.patterns.OriginalCCLax, text)
 
-	return _ParseMailbox(_OriginalToLax, text)
+	return parser._ParseMailbox(parser.patterns.OriginalToLax, text)
 }
 
-func _ParseOriginalCC(text string) []_MailboxResult {
-	recipients := _ParseMailbox(_OriginalCC, text)
+func (parser *Parser) _ParseOriginalCC(text string) ([]Mailbox, *regexp.Regexp) {
+	recipients, pattern := parser._ParseMailbox(parser.patterns.OriginalCC, text)
 
 	if len(recipients) > 0 {
-		return recipients
+		return recipients, pattern
 	}
 
-	text = _LoopRegexesReplace(_OriginalSubjectLax, text)
-	text = _LoopRegexesReplace(_OriginalDateLax, text)
+	text = parser._ReplacePatterns(parser.patterns.OriginalSubjectLax, text)
+	text = parser._ReplacePatterns(parser.patterns.OriginalDateLax, text)
 
-	return _ParseMailbox(_OriginalCCLax, text)
+	return parser._ParseMailbox(parser.patterns.OriginalCCLax, text)
 }
 
-func _ParseOriginalSubject(text string) string {
-	match, _ := _LoopRegexesMatch(_OriginalSubject, text, true)
+func (parser *Parser) _ParseOriginalHeader(regexes []*regexp.Regexp, text string) (string, *regexp.Regexp) {
+	match, pattern := parser._MatchPatterns(regexes, text)
 
 	if len(match) > 0 {
-		return trimString(match[1])
+		return trimString(match[len(match)-1]), pattern
 	}
 
-	match, _ = _LoopRegexesMatch(_OriginalSubjectLax, text, true)
+	return "", nil
+}
 
-	if len(match) > 0 {
-		return trimString(match[1])
+// _ParseMessageID returns the first message identifier of a header value,
+// such as "<1234@acme.com>"
+func _ParseMessageID(value string) string {
+	if ids := _ParseMessageIDs(value); len(ids) > 0 {
+		return ids[0]
 	}
 
 	return ""
 }
 
-func _ParseOriginalDate(text string, body string) string {
-	match, _ := _LoopRegexesMatch(_OriginalDate, text, true)
+// _ParseMessageIDs returns the message identifiers of a header value, such
+// as "<1234@acme.com> <5678@acme.com>"
+func _ParseMessageIDs(value string) []string {
+	ids := _MessageID.FindAllString(value, -1)
+
+	if len(ids) == 0 {
+		ids = strings.FieldsFunc(value, func(r rune) bool {
+			return r == ',' || unicode.IsSpace(r)
+		})
+	}
+
+	if len(ids) == 0 {
+		return []string{}
+	}
+
+	return ids
+}
+
+func (parser *Parser) _ParseOriginalSubject(text string) (string, *regexp.Regexp) {
+	match, pattern := parser._MatchPatterns(parser.patterns.OriginalSubject, text)
+
+	if len(match) > 0 {
+		return trimString(match[1]), pattern
+	}
+
+	match, pattern = parser._MatchPatterns(parser.patterns.OriginalSubjectLax, text)
+
+	if len(match) > 0 {
+		return trimString(match[1]), pattern
+	}
+
+	return "", nil
+}
+
+func (parser *Parser) _ParseOriginalDate(text string, body string) (string, *regexp.Regexp) {
+	match, pattern := parser._MatchPatterns(parser.patterns.OriginalDate, text)
 
 	if len(match) > 0 {
-		return trimString(match[1])
+		return trimString(match[1]), pattern
 	}
 
-	match, pattern := _LoopRegexesMatch(_SeparatorWithInformation, body, true)
+	match, pattern = parser._MatchPatterns(parser.patterns.SeparatorWithInformation, body)
 
 	if len(match) == 4 {
 		namedMatches := findNamedMatches(pattern, body)
 
-		return trimString(namedMatches["date"])
+		return trimString(namedMatches["date"]), nil
 	}
 
-	text = _LoopRegexesReplace(_OriginalSubjectLax, text)
-	match, _ = _LoopRegexesMatch(_OriginalDateLax, text, true)
+	parser._Reject(pattern, "the separator does not hold the three named groups")
+
+	text = parser._ReplacePatterns(parser.patterns.OriginalSubjectLax, text)
+	match, pattern = parser._MatchPatterns(parser.patterns.OriginalDateLax, text)
 
 	if len(match) > 0 {
-		return trimString(match[1])
+		return trimString(match[1]), pattern
 	}
 
-	return ""
+	return "", nil
 }
 
-func _ParseMailbox(regexes []*regexp.Regexp, text string) []_MailboxResult {
-	match, _ := _LoopRegexesMatch(regexes, text, true)
+func (parser *Parser) _ParseMailbox(regexes []*regexp.Regexp, text string) ([]Mailbox, *regexp.Regexp) {
+	match, pattern := parser._MatchPatterns(regexes, text)
 
 	if len(match) > 0 {
 		mailboxesLine := trimString(match[len(match)-1])
 
 		if len(mailboxesLine) > 0 {
-			mailboxes := []_MailboxResult{}
+			return parser._ParseMailboxesLine(mailboxesLine), pattern
+		}
 
-			for len(mailboxesLine) > 0 {
-				mailboxMatch, _ := _LoopRegexesMatch(_Mailbox, mailboxesLine, true)
+		parser._Reject(pattern, "the header is empty")
+	}
 
-				if len(mailboxMatch) > 0 {
-					var name string
-					var address string
+	return []Mailbox{}, nil
+}
 
-					if len(mailboxMatch) == 3 {
-						name = mailboxMatch[1]
-						address = mailboxMatch[2]
-					} else {
-						address = mailboxMatch[1]
-					}
+func (parser *Parser) _ParseMailboxesLine(mailboxesLine string) []Mailbox {
+	mailboxes := []Mailbox{}
 
-					mailboxes = append(mailboxes, _PrepareMailbox(name, address))
+	for len(mailboxesLine) > 0 {
+		mailboxMatch, _ := parser._MatchPatterns(parser.patterns.Mailbox, mailboxesLine)
 
-					mailboxesLine = trimString(strings.Replace(mailboxesLine, mailboxMatch[0], "", 1))
+		// An empty match would not shorten the line
+		if len(mailboxMatch) > 1 && len(mailboxMatch[0]) > 0 {
+			var name string
+			var address string
 
-					if len(mailboxesLine) > 0 {
-						for _, separator := range _MailboxesSeparators {
-							if separator == string(mailboxesLine[0]) {
-								mailboxesLine = trimString(mailboxesLine[1:])
-								break
-							}
-						}
-					}
-				} else {
-					mailboxes = append(mailboxes, _PrepareMailbox("", mailboxesLine))
+			if len(mailboxMatch) == 3 {
+				name = mailboxMatch[1]
+				address = mailboxMatch[2]
+			} else {
+				address = mailboxMatch[1]
+			}
+
+			mailboxes = append(mailboxes, parser._PrepareMailbox(name, address))
+
+			mailboxesLine = trimString(strings.Replace(mailboxesLine, mailboxMatch[0], "", 1))
 
-					mailboxesLine = ""
+			if len(mailboxesLine) > 0 {
+				for _, separator := range _MailboxesSeparators {
+					if separator == string(mailboxesLine[0]) {
+						mailboxesLine = trimString(mailboxesLine[1:])
+						break
+					}
 				}
 			}
+		} else {
+			mailboxes = append(mailboxes, parser._PrepareMailbox("", mailboxesLine))
 
-			return mailboxes
+			mailboxesLine = ""
 		}
 	}
 
-	return []_MailboxResult{}
+	return mailboxes
 }
 
-type _MailboxResult struct {
-	Name    string
-	Address string
+// Mailbox is a single sender or recipient, with an optional display name.
+type Mailbox struct {
+	Name    string `json:"name"`
+	Address string `json:"address"`
 }
 
-func _PrepareMailbox(name string, address string) _MailboxResult {
+// String renders the mailbox in RFC 5322 form, such as
+// "\"John Doe\" <john.doe@acme.com>". A mailbox without an address is
+// rendered as its name only.
+func (mailbox Mailbox) String() string {
+	if len(mailbox.Address) == 0 {
+		return mailbox.Name
+	}
+
+	return (&mail.Address{Name: mailbox.Name, Address: mailbox.Address}).String()
+}
+
+// ParseAddressList parses a list of mailboxes as written in the header block
+// of a forwarded email, such as "John Doe <john.doe@acme.com>, 'Walter
+// Sheltan' [mailto:walter.sheltan@acme.com]".
+func ParseAddressList(list string) []Mailbox {
+	return _DefaultParser.ParseAddressList(list)
+}
+
+// ParseAddressList is like the package-level ParseAddressList, using the
+// parser's mailbox patterns.
+func (parser *Parser) ParseAddressList(list string) []Mailbox {
+	list = trimString(preprocessString(list))
+
+	if len(list) == 0 {
+		return []Mailbox{}
+	}
+
+	return parser._ParseMailboxesLine(list)
+}
+
+func (parser *Parser) _PrepareMailbox(name string, address string) Mailbox {
 	name = trimString(name)
 	address = trimString(address)
 
-	match, _ := _LoopRegexesMatch(_MailboxAddress, address, true)
+	match, _ := parser._MatchPatterns(parser.patterns.MailboxAddress, address)
 
 	if len(match) == 0 {
 		name = address
@@ -286,53 +522,149 @@ func _PrepareMailbox(name string, address string) _MailboxResult {
 		name = ""
 	}
 
-	return _MailboxResult{
+	return Mailbox{
 		Name:    name,
 		Address: address,
 	}
 }
 
 type ReadResultEmail struct {
-	Body    string
-	From    _MailboxResult
-	To      []_MailboxResult
-	CC      []_MailboxResult
-	Subject string
-	Date    string
+	Body      string    `json:"body"`
+	BodyHTML  string    `json:"bodyHtml"`
+	From      Mailbox   `json:"from"`
+	To        []Mailbox `json:"to"`
+	CC        []Mailbox `json:"cc"`
+	BCC       []Mailbox `json:"bcc"`
+	ReplyTo   []Mailbox `json:"replyTo"`
+	Subject   string    `json:"subject"`
+	Date      string    `json:"date"`
+	MessageID string    `json:"messageId"`
+
+	// InReplyTo and References are the identifiers of the emails the
+	// original email replied to, when the client wrote them.
+	InReplyTo  string   `json:"inReplyTo"`
+	References []string `json:"references"`
+
+	// DateTime is Date parsed by ParseDate, or the zero time if it could not
+	// be parsed; DateAmbiguity tells which of its parts had to be guessed.
+	DateTime      time.Time     `json:"dateTime,omitempty"`
+	DateAmbiguity DateAmbiguity `json:"dateAmbiguity"`
 }
 
+// ReadResult is the result of reading a forward. Its JSON encoding is
+// described by JSONSchema.
 type ReadResult struct {
-	Forwarded bool
-	Message   string
-	Email     ReadResultEmail
+	Forwarded bool            `json:"forwarded"`
+	Message   string          `json:"message"`
+	Email     ReadResultEmail `json:"email"`
+
+	// Signature is the signature and disclaimers of the forwarder, split off
+	// the end of Message by parsers returned by WithSignatures.
+	Signature string `json:"signature,omitempty"`
+
+	// Kind tells forwards from replies, by the prefix of the subject and the
+	// separator. A reply is not Forwarded, unless it was read without its
+	// subject and quotes the email after a separator.
+	Kind Kind `json:"kind"`
+
+	// Client and Locale are the email client (e.g. "Apple Mail", "Outlook
+	// 2019") and locale (e.g. "de", "pt-br") that most likely produced the
+	// forward, guessed from the patterns that matched it. They are empty if
+	// unknown, or if the patterns are shared by several clients, and Locale
+	// alone is empty if they are shared by several locales of the client.
+	Client string `json:"client"`
+	Locale string `json:"locale"`
+
+	// Matches tells how each field was found, and how reliably; its
+	// Confidence method sums it up for the whole forward.
+	Matches ReadMatches `json:"matches"`
+
+	// Spans holds the positions of the parts of the forward in the body, if
+	// it was read with ReadWithSpans.
+	Spans *ReadSpans `json:"spans,omitempty"`
 }
 
+// Read parses a forwarded email from its body and, optionally, its subject,
+// using the default patterns. It does not panic on any input, including
+// invalid UTF-8.
 func Read(body string, subject string) ReadResult {
+	return _DefaultParser.Read(body, subject)
+}
+
+// Read is like the package-level Read, using the parser's patterns.
+func (parser *Parser) Read(body string, subject string) ReadResult {
+	result, _ := parser._Read(body, subject, false)
+
+	return result
+}
+
+// ReadWithSpans is like Read, and also returns the positions of the message,
+// separator, header lines and original body in body, in ReadResult.Spans.
+func ReadWithSpans(body string, subject string) ReadResult {
+	return _DefaultParser.ReadWithSpans(body, subject)
+}
+
+// ReadWithSpans is like the package-level ReadWithSpans, using the parser's
+// patterns.
+func (parser *Parser) ReadWithSpans(body string, subject string) ReadResult {
+	result, _ := parser._Read(body, subject, true)
+
+	return result
+}
+
+// _Read returns the result, and ErrNotForwarded, ErrNoSeparator or
+// ErrHeadersNotFound if the body could not be read as a forward
+func (parser *Parser) _Read(body string, subject string, spans bool) (ReadResult, error) {
+	input := body
+	var offsets []int
+
+	if spans {
+		offsets = _IdentityOffsets(len(body))
+	}
+
 	email := _ParseOriginalEmailResult{}
 	forwarded := false
 	bodyResult := _ParseBodyResult{}
 	parsedSubject := ""
+	var subjectPattern *regexp.Regexp
+	prefixKind := KindForward
 
 	if len(subject) > 0 {
-		subject = preprocessString(strings.Clone(subject))
-		parsedSubject = _ParseSubject(subject)
+		subject = preprocessString(subject)
+		parsedSubject, subjectPattern = parser._ParseSubject(subject)
 
 		if len(parsedSubject) > 0 {
 			forwarded = true
 		}
 	}
 
+	replyPattern := parser._ParseReplySubject(subject)
+
 	if len(subject) == 0 || forwarded {
-		body = preprocessString(strings.Clone(body))
-		bodyResult = _ParseBody(body, forwarded)
+		body, offsets = _PreprocessMapped(body, offsets)
+		bodyResult = parser._ParseBody(body, forwarded, offsets)
+
+		if parser.signatures {
+			bodyResult = parser._SplitSignature(bodyResult)
+		}
 
 		if len(bodyResult.Email) > 0 {
 			forwarded = true
 
-			email = _ParseOriginalEmail(bodyResult.Email, bodyResult.Body)
+			email = parser._ParseOriginalEmail(bodyResult.Email, bodyResult.Body, bodyResult.EmailOffsets)
 		}
 	}
 
+	if subjectPattern != nil && replyPattern != nil {
+		prefixKind = parser._PrefixKind(subjectPattern, replyPattern, bodyResult, email)
+	}
+
+	// A reply is read as if its subject had no forward prefix
+	if prefixKind == KindReply {
+		forwarded, parsedSubject, subjectPattern = false, "", nil
+		bodyResult, email = _ParseBodyResult{}, _ParseOriginalEmailResult{}
+	}
+
 	subjectResult := ""
 
 	if len(parsedSubject) > 0 {
@@ -341,18 +673,106 @@ func Read(body string, subject string) ReadResult {
 		subjectResult = email.Subject
 	}
 
+	client, locale := "", ""
+
+	if forwarded {
+		patterns := []*regexp.Regexp{bodyResult.Separator, subjectPattern, email.Headers.From, email.Headers.Subject, email.Headers.Date}
+
+		client, locale = parser._DetectClient(patterns, email.Date)
+	}
+
+	dateTime, dateAmbiguity, _ := ParseDate(email.Date, locale)
+
+	matches := ReadMatches{}
+
+	if forwarded {
+		matches = parser._NewReadMatches(bodyResult, subjectPattern, email)
+	}
+
+	var readSpans *ReadSpans
+
+	if spans {
+		readSpans = _NewReadSpans(input, bodyResult, email)
+	}
+
+	kind := parser._Kind(input, subjectPattern, replyPattern, forwarded, bodyResult.Separator)
+
+	if prefixKind == KindUnknown {
+		kind = KindUnknown
+	}
+
+	var err error
+
+	switch {
+	case bodyResult.Truncated:
+		err = ErrHeadersNotFound
+	case !forwarded:
+		err = ErrNotForwarded
+	case len(bodyResult.Email) == 0:
+		err = ErrNoSeparator
+	case email.From == (Mailbox{}):
+		err = ErrHeadersNotFound
+	}
+
 	return ReadResult{
 		Forwarded: forwarded,
 
-		Message: bodyResult.Message,
+		Message:   bodyResult.Message,
+		Signature: bodyResult.Signature,
 
 		Email: ReadResultEmail{
-			Body:    email.Body,
-			From:    email.From,
-			To:      email.To,
-			CC:      email.CC,
-			Subject: subjectResult,
-			Date:    email.Date,
+			Body:          email.Body,
+			From:          email.From,
+			To:            email.To,
+			CC:            email.CC,
+			BCC:           email.BCC,
+			ReplyTo:       email.ReplyTo,
+			Subject:       subjectResult,
+			Date:          email.Date,
+			MessageID:     email.MessageID,
+			InReplyTo:     email.InReplyTo,
+			References:    email.References,
+			DateTime:      dateTime,
+			DateAmbiguity: dateAmbiguity,
 		},
+
+		Kind: kind,
+
+		Client: client,
+		Locale: locale,
+
+		Matches: matches,
+
+		Spans: readSpans,
+	}, err
+}
+
+const _MaxChainLength = 32
+
+// ReadChain parses a message that was forwarded several times, descending
+// into each forwarded body in turn. It returns one result per hop, starting
+// with the outermost forward; the result is empty if the message is not a
+// forward.
+func ReadChain(body string, subject string) []ReadResult {
+	return _DefaultParser.ReadChain(body, subject)
+}
+
+// ReadChain is like the package-level ReadChain, using the parser's patterns.
+func (parser *Parser) ReadChain(body string, subject string) []ReadResult {
+	hops := []ReadResult{}
+
+	for len(hops) < _MaxChainLength {
+		result := parser.Read(body, subject)
+
+		if !result.Forwarded {
+			break
+		}
+
+		hops = append(hops, result)
+
+		body = result.Email.Body
+		subject = ""
 	}
+
+	return hops
 }
@@ -696,3 +696,169 @@ func TestAlternative15(t *testing.T) {
 		}
 	})
 }
+
+func TestAlternative16(t *testing.T) {
+	_LoopTests([]string{
+		"apple_mail_de_body_variant_16",
+		"thunderbird_en_body_variant_16",
+	}, func(result ReadResult, entryName string) {
+		_TestEmail(t, result, entryName, false, false, false, true, false)
+
+		if len(result.Email.BCC) != 1 || result.Email.BCC[0].Name != _TestToName2 || result.Email.BCC[0].Address != _TestToAddress2 {
+			t.Error(entryName, "unexpected result.Email.BCC", result.Email.BCC)
+		}
+
+		if len(result.Email.ReplyTo) != 1 || result.Email.ReplyTo[0].Name != _TestFromName || result.Email.ReplyTo[0].Address != _TestFromAddress {
+			t.Error(entryName, "unexpected result.Email.ReplyTo", result.Email.ReplyTo)
+		}
+
+		switch entryName {
+		case "thunderbird_en_body_variant_16":
+			if result.Email.MessageID != "<4f1b2c3d-0e9a@acme.com>" {
+				t.Error(entryName, "unexpected result.Email.MessageID", result.Email.MessageID)
+			}
+
+			if result.Email.InReplyTo != "<9a8b7c6d-5e4f@globex.corp>" {
+				t.Error(entryName, "unexpected result.Email.InReplyTo", result.Email.InReplyTo)
+			}
+
+			if strings.Join(result.Email.References, " ") != "<1a2b3c4d-5e6f@globex.corp> <9a8b7c6d-5e4f@globex.corp>" {
+				t.Error(entryName, "unexpected result.Email.References", result.Email.References)
+			}
+		}
+	})
+}
+
+func TestReadChain(t *testing.T) {
+	email, subject := _Read("gmail_en_body", "")
+
+	email = strings.Join([]string{
+		"Please see below.",
+		"",
+		"---------- Forwarded message ---------",
+		"From: Bessie Berry <bessie.berry@acme.com>",
+		"Date: Thu, Oct 28, 2021 at 10:02 AM",
+		"Subject: Fwd: Integer consequat non purus",
+		"To: Suzanne <suzanne@globex.corp>",
+		"",
+		_TestMessage,
+		"",
+		email,
+	}, "\n")
+
+	hops := ReadChain(email, subject)
+
+	if len(hops) != 2 {
+		t.Fatal("len(hops) != 2", len(hops))
+	}
+
+	if hops[0].Message != "Please see below." {
+		t.Error("hops[0].Message != \"Please see below.\"", hops[0].Message)
+	}
+
+	if hops[0].Email.From.Address != _TestToAddress1 {
+		t.Error("hops[0].Email.From.Address != _TestToAddress1", hops[0].Email.From.Address)
+	}
+
+	if hops[0].Email.To[0].Address != _TestToAddress2 {
+		t.Error("hops[0].Email.To[0].Address != _TestToAddress2", hops[0].Email.To[0].Address)
+	}
+
+	_TestEmail(t, hops[1], "gmail_en_body", false, false, false, false, false)
+
+	if len(ReadChain(_TestBody, "")) != 0 {
+		t.Error("len(ReadChain(_TestBody)) != 0")
+	}
+}
+
+// _FirstOriginalBody is the body _ParseOriginalBody chose before ReadChain:
+// after the first header, in pattern order, ending the header block
+func _FirstOriginalBody(parser *Parser, text string) string {
+	for _, regexes := range [][]*regexp.Regexp{
+		parser.patterns.OriginalSubject,
+		parser.patterns.OriginalCC,
+		parser.patterns.OriginalTo,
+		parser.patterns.OriginalReplyTo,
+		parser.patterns.OriginalBCC,
+		parser.patterns.OriginalMessageID,
+		parser.patterns.OriginalInReplyTo,
+		parser.patterns.OriginalReferences,
+	} {
+		match, _ := parser._SplitPatterns(regexes, text)
+
+		if len(match) > 3 && strings.HasPrefix(match[3], "\n\n") {
+			return trimString(reconciliateSplitMatch(match, 4, []int{3}, func(i int) bool { return i%3 == 2 }))
+		}
+	}
+
+	return ""
+}
+
+// Choosing the header closest to the top to end the header block, for
+// ReadChain, leaves the body of single forwards unchanged
+func TestParseOriginalBodySingleForward(t *testing.T) {
+	parser := *_DefaultParser
+
+	for _, fixture := range _ReadFixtures(t) {
+		body := parser._ParseBody(preprocessString(fixture.Body), true, nil)
+		if len(body.Email) == 0 {
+			continue
+		}
+
+		email := parser._ParseOriginalEmail(body.Email, body.Body, nil)
+
+		if expected := _FirstOriginalBody(&parser, email.Text); len(expected) > 0 && email.Body != expected {
+			t.Errorf("%s: unexpected body %q, expected %q", fixture.Name, email.Body, expected)
+		}
+	}
+}
+
+func TestParseAddressList(t *testing.T) {
+	mailboxes := ParseAddressList("Walter Sheltan <walter.sheltan@acme.com<mailto:walter.sheltan@acme.com>>, Nicholas [mailto:nicholas@globex.corp]; <suzanne@globex.corp>, bessie.berry@acme.com")
+
+	expected := []Mailbox{
+		{Name: "Walter Sheltan", Address: "walter.sheltan@acme.com"},
+		{Name: "Nicholas", Address: "nicholas@globex.corp"},
+		{Address: "suzanne@globex.corp"},
+		{Address: "bessie.berry@acme.com"},
+	}
+
+	if len(mailboxes) != len(expected) {
+		t.Fatal("unexpected mailboxes", mailboxes)
+	}
+
+	for i, mailbox := range mailboxes {
+		if mailbox != expected[i] {
+			t.Error("unexpected mailbox", i, mailbox)
+		}
+	}
+
+	if len(ParseAddressList(" ")) != 0 {
+		t.Error("len(ParseAddressList(\" \")) != 0")
+	}
+}
+
+func TestMailboxString(t *testing.T) {
+	for mailbox, expected := range map[Mailbox]string{
+		{Name: "John Doe", Address: "john.doe@acme.com"}:  "\"John Doe\" <john.doe@acme.com>",
+		{Name: "Doe, John", Address: "john.doe@acme.com"}: "\"Doe, John\" <john.doe@acme.com>",
+		{Address: "john.doe@acme.com"}:                    "<john.doe@acme.com>",
+		{Name: "Walter Sheltan"}:                          "Walter Sheltan",
+		{Name: "Jérôme", Address: "jerome@acme.com"}:      "=?utf-8?q?J=C3=A9r=C3=B4me?= <jerome@acme.com>",
+	} {
+		if mailbox.String() != expected {
+			t.Error("unexpected string", mailbox.String())
+		}
+	}
+}
+
+// A header block that ends the body used to index match[3] out of range
+func TestReadHeaderBlockAtEnd(t *testing.T) {
+	for _, header := range []string{"Subject: " + _TestSubject, "To: " + _TestToAddress1} {
+		result := Read("---------- Forwarded message ---------\nFrom: "+_TestFromName+" <"+_TestFromAddress+">\n"+header, "")
+
+		if !result.Forwarded || result.Email.From.Address != _TestFromAddress {
+			t.Errorf("unexpected result %+v", result)
+		}
+	}
+}
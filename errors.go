@@ -0,0 +1,49 @@
+package emailforwardparser
+
+import (
+	"context"
+	"errors"
+)
+
+// The errors of ReadWithError, telling why a body could not be read as a
+// forward.
+var (
+	// ErrNotForwarded is returned when neither the subject nor the body
+	// mark the email as a forward.
+	ErrNotForwarded = errors.New("emailforwardparser: not a forward")
+	// ErrNoSeparator is returned when the subject marks the email as a
+	// forward, but the body holds no separator or header block to find the
+	// original email in.
+	ErrNoSeparator = errors.New("emailforwardparser: no separator found")
+	// ErrHeadersNotFound is returned when the original email was found but
+	// not its sender, as when the body ends at the separator.
+	ErrHeadersNotFound = errors.New("emailforwardparser: headers of the original email not found")
+	// ErrInputTooLarge is matched by the *InputTooLargeError returned when
+	// the body or subject exceeds the limits of the parser.
+	ErrInputTooLarge = errors.New("emailforwardparser: input too large")
+)
+
+// ReadWithError is like ReadContext, and also returns ErrNotForwarded,
+// ErrNoSeparator or ErrHeadersNotFound, along with the partial result, when
+// the body could not be read as a forward; the result of Read would then be
+// empty or lack the original sender.
+func ReadWithError(ctx context.Context, body string, subject string) (ReadResult, error) {
+	return _DefaultParser.ReadWithError(ctx, body, subject)
+}
+
+// ReadWithError is like the package-level ReadWithError, using the parser's
+// patterns and limits.
+func (parser *Parser) ReadWithError(ctx context.Context, body string, subject string) (ReadResult, error) {
+	bounded, err := parser._Bound(ctx, body, subject)
+	if err != nil {
+		return ReadResult{}, err
+	}
+
+	result, readErr := bounded._Read(body, subject, false)
+
+	if err := ctx.Err(); err != nil {
+		return ReadResult{}, err
+	}
+
+	return result, readErr
+}
@@ -0,0 +1,44 @@
+package emailforwardparser
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestReadWithError(t *testing.T) {
+	email, _ := _Read("gmail_en_body", "")
+
+	for _, test := range []struct {
+		Name    string
+		Body    string
+		Subject string
+		Err     error
+	}{
+		{"forward", email, "", nil},
+		{"message", _TestMessage, "", ErrNotForwarded},
+		{"message with a subject", email, _TestSubject, ErrNotForwarded},
+		{"forward without separator", _TestMessage, "Fwd: " + _TestSubject, ErrNoSeparator},
+		{"separator without headers", _TestMessage + "\n\n---------- Forwarded message ---------\n" + _TestBody, "", ErrHeadersNotFound},
+		{"truncated", _TestMessage + "\n\n---------- Forwarded message ---------\n", "", ErrHeadersNotFound},
+		{"too large", strings.Repeat(" ", DefaultLimits.MaxBodySize+1), "", ErrInputTooLarge},
+	} {
+		result, err := ReadWithError(context.Background(), test.Body, test.Subject)
+
+		if !errors.Is(err, test.Err) || (test.Err == nil) != (err == nil) {
+			t.Errorf("%s: unexpected error %v, expected %v", test.Name, err, test.Err)
+		}
+
+		if test.Err == ErrNoSeparator && (!result.Forwarded || result.Email.Subject != _TestSubject) {
+			t.Errorf("%s: the partial result was not returned: %+v", test.Name, result)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := ReadWithError(ctx, _TestMessage, ""); err != context.Canceled {
+		t.Error("unexpected error", err)
+	}
+}
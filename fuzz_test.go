@@ -0,0 +1,153 @@
+package emailforwardparser
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	regexp "github.com/wasilibs/go-re2"
+)
+
+// _AddFixtures seeds a fuzz target with the bodies and subjects of the
+// fixtures directory
+func _AddFixtures(f *testing.F, add func(fixture _Fixture)) {
+	for _, fixture := range _ReadFixtures(f) {
+		add(fixture)
+	}
+}
+
+func FuzzRead(f *testing.F) {
+	_AddFixtures(f, func(fixture _Fixture) {
+		f.Add(fixture.Body, fixture.Subject)
+	})
+
+	f.Add("\n\n---------- Forwarded message ---------\n", "Fwd:")
+	f.Add("---------- Forwarded message ---------\nFrom: a <a@b.com>\nTo: b@c.com", "")
+	f.Add("Subject: \xff\n\n\xfe", "\xff")
+
+	f.Fuzz(func(t *testing.T, body string, subject string) {
+		Read(body, subject)
+
+		result := ReadWithSpans(body, subject)
+
+		for _, span := range []Span{
+			result.Spans.Message, result.Spans.Separator,
+			result.Spans.From, result.Spans.To, result.Spans.CC, result.Spans.BCC, result.Spans.ReplyTo,
+			result.Spans.Subject, result.Spans.Date,
+			result.Spans.MessageID, result.Spans.InReplyTo, result.Spans.References,
+			result.Spans.Body,
+		} {
+			if span.Start < 0 || span.Start > span.End || span.End > len(body) {
+				t.Errorf("span %+v out of the body of %d bytes", span, len(body))
+			}
+		}
+	})
+}
+
+func FuzzReadChain(f *testing.F) {
+	_AddFixtures(f, func(fixture _Fixture) {
+		f.Add(fixture.Body, fixture.Subject)
+	})
+
+	f.Fuzz(func(t *testing.T, body string, subject string) {
+		if hops := ReadChain(body, subject); len(hops) > _MaxChainLength {
+			t.Errorf("%d hops", len(hops))
+		}
+	})
+}
+
+func FuzzReadHTML(f *testing.F) {
+	names, err := filepath.Glob("./fixtures/*.html")
+	if err != nil {
+		f.Fatal(err)
+	}
+
+	for _, name := range names {
+		data, err := os.ReadFile(name)
+		if err != nil {
+			f.Fatal(err)
+		}
+
+		f.Add(string(data), "")
+	}
+
+	f.Add("<style>\xff\xff\xff\xff</style", "Fwd: x")
+	f.Add("<div id=divRplyFwdMsg><textarea>&amp;</TEXTAREA><p>From: a</p>", "")
+
+	f.Fuzz(func(t *testing.T, body string, subject string) {
+		ReadHTML(body, subject)
+	})
+}
+
+func FuzzReadMessage(f *testing.F) {
+	_AddFixtures(f, func(fixture _Fixture) {
+		f.Add("Subject: " + fixture.Subject + "\r\nContent-Type: text/plain; charset=utf-8\r\n\r\n" + fixture.Body)
+	})
+
+	f.Add("Content-Type: multipart/mixed; boundary=b\r\n\r\n--b\r\nContent-Type: message/rfc822\r\n\r\nFrom: a <a@b.com>\r\n\r\nx\r\n--b--")
+	f.Add("Content-Type: text/html; charset=iso-8859-1\r\nContent-Transfer-Encoding: base64\r\n\r\nPHN0eWxlPv8=")
+
+	f.Fuzz(func(t *testing.T, message string) {
+		ReadMessage(strings.NewReader(message))
+	})
+}
+
+func FuzzParseMailbox(f *testing.F) {
+	_AddFixtures(f, func(fixture _Fixture) {
+		f.Add(fixture.Body)
+	})
+
+	f.Add("To: 'Walter Sheltan' <walter.sheltan@acme.com<mailto:walter.sheltan@acme.com>>, <>;")
+
+	patterns := _DefaultParser.patterns
+
+	f.Fuzz(func(t *testing.T, text string) {
+		for _, regexes := range [][]*regexp.Regexp{patterns.OriginalTo, patterns.OriginalToLax, patterns.OriginalCC} {
+			_DefaultParser._ParseMailbox(regexes, text)
+		}
+
+		ParseAddressList(text)
+	})
+}
+
+func FuzzSplitWithRegexp(f *testing.F) {
+	separators := _DefaultPatternSet.Separator
+
+	for i, fixture := range _ReadFixtures(f) {
+		f.Add(separators[i%len(separators)].Expr, fixture.Body)
+	}
+
+	f.Add(`(a)|(b)`, "ab")
+	f.Add(`(x)?`, "yx")
+
+	f.Fuzz(func(t *testing.T, expr string, str string) {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return
+		}
+
+		split := splitWithRegexp(re, str)
+
+		if len(split) == 0 || !strings.HasPrefix(str, split[0]) {
+			t.Errorf("unexpected split %q", split)
+		}
+	})
+}
+
+func FuzzNormalizeSubject(f *testing.F) {
+	_AddFixtures(f, func(fixture _Fixture) {
+		f.Add(fixture.Subject)
+	})
+
+	f.Add("[team] Re: [Fwd: [ops] Budget]")
+	f.Add("[[Fwd:]] ]Re: [")
+
+	f.Fuzz(func(t *testing.T, subject string) {
+		normalized, prefixes := NormalizeSubject(subject)
+
+		if len(normalized) > len(subject) || (len(prefixes) == 0) != (normalized == trimString(preprocessString(subject))) {
+			t.Errorf("unexpected subject %q and prefixes %q", normalized, prefixes)
+		}
+	})
+}
@@ -0,0 +1,177 @@
+package emailforwardparser
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var _Update = flag.Bool("update", false, "write the golden files of the fixtures")
+
+// TestGolden compares the result of each fixture with its golden file, such
+// as fixtures/gmail_en_body.golden.json for fixtures/gmail_en_body.txt (read
+// with fixtures/gmail_en_subject.txt as subject if it exists), and
+// fixtures/gmail_en_body.html.golden.json for fixtures/gmail_en_body.html
+// (read with ReadHTML)
+func TestGolden(t *testing.T) {
+	names := map[string]bool{}
+	html := map[string]bool{}
+	fixtures := _ReadFixtures(t)
+
+	for _, fixture := range _ReadHTMLFixtures(t) {
+		html[fixture.Name] = true
+		fixtures = append(fixtures, fixture)
+	}
+
+	for _, fixture := range fixtures {
+		fixture := fixture
+		path := filepath.Join("fixtures", fixture.Name+".golden.json")
+
+		names[path] = true
+
+		t.Run(fixture.Name, func(t *testing.T) {
+			read := Read
+			if html[fixture.Name] {
+				read = ReadHTML
+			}
+
+			data, err := json.MarshalIndent(read(fixture.Body, fixture.Subject), "", "  ")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			actual := string(data) + "\n"
+
+			if *_Update {
+				if err := os.WriteFile(path, []byte(actual), 0o644); err != nil {
+					t.Fatal(err)
+				}
+
+				return
+			}
+
+			expected, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err, "(go test -run TestGolden -update writes it)")
+			}
+
+			if string(expected) != actual {
+				t.Errorf("the result differs from %s (-expected +actual):\n%s", path, _DiffLines(string(expected), actual))
+			}
+		})
+	}
+
+	paths, err := filepath.Glob(filepath.Join("fixtures", "*.golden.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, path := range paths {
+		if names[path] {
+			continue
+		}
+
+		if *_Update {
+			os.Remove(path)
+		} else {
+			t.Errorf("%s has no fixture", path)
+		}
+	}
+}
+
+// Every subject file is the subject of a fixture, read with its body
+func TestFixtureSubjects(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join("fixtures", "*_subject*.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	subjects := map[string]bool{}
+
+	for _, fixture := range _ReadFixtures(t) {
+		if i := strings.IndexByte(fixture.Name, ','); i >= 0 {
+			subjects[fixture.Name[i+1:]] = true
+		} else if len(fixture.Subject) > 0 {
+			subjects[strings.Replace(fixture.Name, "_body", "_subject", 1)] = true
+		}
+	}
+
+	for _, path := range paths {
+		if !subjects[strings.TrimSuffix(filepath.Base(path), ".txt")] {
+			t.Errorf("%s is the subject of no fixture, see fixtures/pairs.csv", path)
+		}
+	}
+}
+
+// _DiffLines returns the lines removed from expected (-) and added in actual
+// (+), with the lines around them
+func _DiffLines(expected string, actual string) string {
+	a, b := strings.Split(expected, "\n"), strings.Split(actual, "\n")
+
+	// The length of the longest common subsequence of a[i:] and b[j:]
+	lengths := make([][]int, len(a)+1)
+
+	for i := range lengths {
+		lengths[i] = make([]int, len(b)+1)
+	}
+
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else if lengths[i+1][j] >= lengths[i][j+1] {
+				lengths[i][j] = lengths[i+1][j]
+			} else {
+				lengths[i][j] = lengths[i][j+1]
+			}
+		}
+	}
+
+	lines := []string{}
+	i, j := 0, 0
+
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			lines = append(lines, "  "+a[i])
+			i++
+			j++
+		case j < len(b) && (i == len(a) || lengths[i][j+1] > lengths[i+1][j]):
+			lines = append(lines, "+ "+b[j])
+			j++
+		default:
+			lines = append(lines, "- "+a[i])
+			i++
+		}
+	}
+
+	// Only keep the changes and the 3 lines around them
+	kept := []string{}
+
+	for k, line := range lines {
+		near := false
+
+		for l := k - 3; l <= k+3; l++ {
+			if l >= 0 && l < len(lines) && !strings.HasPrefix(lines[l], "  ") {
+				near = true
+			}
+		}
+
+		if near {
+			kept = append(kept, line)
+		}
+	}
+
+	return strings.Join(kept, "\n")
+}
+
+func TestDiffLines(t *testing.T) {
+	diff := _DiffLines("a\nb\nc\nd\ne\nf\ng\nh\ni", "a\nb\nc\nd\nE\nf\ng\nh\ni\nj")
+
+	if diff != "  b\n  c\n  d\n- e\n+ E\n  f\n  g\n  h\n  i\n+ j" {
+		t.Errorf("unexpected diff:\n%s", diff)
+	}
+}
@@ -0,0 +1,738 @@
+package emailforwardparser
+
+import (
+	"html"
+	"strings"
+)
+
+type _HTMLTokenType int
+
+const (
+	_HTMLText _HTMLTokenType = iota
+	_HTMLStartTag
+	_HTMLEndTag
+	_HTMLSelfClosingTag
+	_HTMLComment
+)
+
+type _HTMLAttribute struct {
+	Name  string
+	Value string
+}
+
+type _HTMLToken struct {
+	Type  _HTMLTokenType
+	Name  string
+	Attrs []_HTMLAttribute
+	Raw   string
+	Start int
+	End   int
+}
+
+func (token _HTMLToken) Attr(name string) string {
+	for _, attr := range token.Attrs {
+		if attr.Name == name {
+			return attr.Value
+		}
+	}
+
+	return ""
+}
+
+var _HTMLRawTextElements = map[string]bool{
+	"script":   true,
+	"style":    true,
+	"title":    true,
+	"textarea": true,
+}
+
+var _HTMLVoidElements = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true, "hr": true, "img": true,
+	"input": true, "link": true, "meta": true, "source": true, "track": true, "wbr": true,
+}
+
+var _HTMLBlockElements = map[string]bool{
+	"address": true, "article": true, "aside": true, "blockquote": true, "center": true, "dd": true,
+	"div": true, "dl": true, "dt": true, "fieldset": true, "figure": true, "footer": true, "form": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true, "header": true, "hr": true,
+	"li": true, "main": true, "nav": true, "ol": true, "p": true, "pre": true, "section": true,
+	"table": true, "tbody": true, "tfoot": true, "thead": true, "tr": true, "ul": true,
+}
+
+var _HTMLSkippedElements = map[string]bool{
+	"head":     true,
+	"script":   true,
+	"style":    true,
+	"title":    true,
+	"template": true,
+}
+
+func _TokenizeHTML(s string) []_HTMLToken {
+	tokens := []_HTMLToken{}
+	i := 0
+
+	for i < len(s) {
+		if s[i] != '<' {
+			end := strings.IndexByte(s[i:], '<')
+			if end < 0 {
+				end = len(s)
+			} else {
+				end += i
+			}
+
+			tokens = append(tokens, _HTMLToken{Type: _HTMLText, Raw: s[i:end], Start: i, End: end})
+			i = end
+
+			continue
+		}
+
+		if strings.HasPrefix(s[i:], "<!--") {
+			end := strings.Index(s[i+4:], "-->")
+			if end < 0 {
+				end = len(s)
+			} else {
+				end += i + 4 + 3
+			}
+
+			tokens = append(tokens, _HTMLToken{Type: _HTMLComment, Raw: s[i:end], Start: i, End: end})
+			i = end
+
+			continue
+		}
+
+		token, ok := _ReadHTMLTag(s, i)
+		if !ok {
+			tokens = append(tokens, _HTMLToken{Type: _HTMLText, Raw: s[i : i+1], Start: i, End: i + 1})
+			i++
+
+			continue
+		}
+
+		tokens = append(tokens, token)
+		i = token.End
+
+		if token.Type == _HTMLStartTag && _HTMLRawTextElements[token.Name] {
+			end := _IndexASCIIFold(s[i:], "</"+token.Name)
+			if end < 0 {
+				end = len(s)
+			} else {
+				end += i
+			}
+
+			if end > i {
+				tokens = append(tokens, _HTMLToken{Type: _HTMLText, Raw: s[i:end], Start: i, End: end})
+			}
+
+			i = end
+		}
+	}
+
+	return tokens
+}
+
+// _IndexASCIIFold is strings.Index ignoring the case of ASCII letters, given
+// a lowercase substr. Unlike searching strings.ToLower(s), the index is in s
+// even if s is not valid UTF-8.
+func _IndexASCIIFold(s string, substr string) int {
+	for i := 0; i+len(substr) <= len(s); i++ {
+		j := 0
+
+		for j < len(substr) && _LowerASCII(s[i+j]) == substr[j] {
+			j++
+		}
+
+		if j == len(substr) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func _LowerASCII(b byte) byte {
+	if b >= 'A' && b <= 'Z' {
+		return b + 'a' - 'A'
+	}
+
+	return b
+}
+
+func _ReadHTMLTag(s string, start int) (_HTMLToken, bool) {
+	i := start + 1
+	token := _HTMLToken{Type: _HTMLStartTag, Start: start}
+
+	if i < len(s) && (s[i] == '!' || s[i] == '?') {
+		end := strings.IndexByte(s[i:], '>')
+		if end < 0 {
+			return token, false
+		}
+
+		token.Type = _HTMLComment
+		token.End = i + end + 1
+		token.Raw = s[start:token.End]
+
+		return token, true
+	}
+
+	if i < len(s) && s[i] == '/' {
+		token.Type = _HTMLEndTag
+		i++
+	}
+
+	nameStart := i
+	for i < len(s) && _IsHTMLNameByte(s[i]) {
+		i++
+	}
+
+	if i == nameStart {
+		return token, false
+	}
+
+	token.Name = strings.ToLower(s[nameStart:i])
+
+	for i < len(s) {
+		for i < len(s) && _IsHTMLSpace(s[i]) {
+			i++
+		}
+
+		if i >= len(s) {
+			return token, false
+		}
+
+		if s[i] == '>' {
+			i++
+			break
+		}
+
+		if s[i] == '/' {
+			if i+1 < len(s) && s[i+1] == '>' {
+				if token.Type == _HTMLStartTag {
+					token.Type = _HTMLSelfClosingTag
+				}
+
+				i += 2
+				break
+			}
+
+			i++
+			continue
+		}
+
+		attrStart := i
+		for i < len(s) && !_IsHTMLSpace(s[i]) && s[i] != '=' && s[i] != '>' && !(s[i] == '/' && i+1 < len(s) && s[i+1] == '>') {
+			i++
+		}
+
+		attr := _HTMLAttribute{Name: strings.ToLower(s[attrStart:i])}
+
+		for i < len(s) && _IsHTMLSpace(s[i]) {
+			i++
+		}
+
+		if i < len(s) && s[i] == '=' {
+			i++
+
+			for i < len(s) && _IsHTMLSpace(s[i]) {
+				i++
+			}
+
+			if i < len(s) && (s[i] == '"' || s[i] == '\'') {
+				quote := s[i]
+				end := strings.IndexByte(s[i+1:], quote)
+				if end < 0 {
+					return token, false
+				}
+
+				attr.Value = html.UnescapeString(s[i+1 : i+1+end])
+				i += end + 2
+			} else {
+				valueStart := i
+				for i < len(s) && !_IsHTMLSpace(s[i]) && s[i] != '>' {
+					i++
+				}
+
+				attr.Value = html.UnescapeString(s[valueStart:i])
+			}
+		}
+
+		token.Attrs = append(token.Attrs, attr)
+	}
+
+	if token.Type == _HTMLStartTag && _HTMLVoidElements[token.Name] {
+		token.Type = _HTMLSelfClosingTag
+	}
+
+	token.End = i
+	token.Raw = s[start:i]
+
+	return token, true
+}
+
+func _IsHTMLNameByte(b byte) bool {
+	return b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z' || b >= '0' && b <= '9' || b == '-' || b == ':'
+}
+
+func _IsHTMLSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r' || b == '\f'
+}
+
+type _RenderedHTML struct {
+	Text []byte
+
+	// Starts and Ends hold, for every byte of Text, the range of the HTML
+	// source it was produced from
+	Starts []int
+	Ends   []int
+
+	// Quote is the depth of the quotes being rendered, whose lines are
+	// prefixed with ">" as in the plain text version
+	Quote int
+}
+
+func (t *_RenderedHTML) write(s string, start int, end int) {
+	if t.Quote > 0 && (len(t.Text) == 0 || t.hasSuffix("\n")) {
+		t.append(strings.Repeat(">", t.Quote)+" ", start, end)
+	}
+
+	t.append(s, start, end)
+}
+
+func (t *_RenderedHTML) append(s string, start int, end int) {
+	t.Text = append(t.Text, s...)
+
+	for i := 0; i < len(s); i++ {
+		t.Starts = append(t.Starts, start)
+		t.Ends = append(t.Ends, end)
+	}
+}
+
+func (t *_RenderedHTML) hasSuffix(suffix string) bool {
+	return len(t.Text) >= len(suffix) && string(t.Text[len(t.Text)-len(suffix):]) == suffix
+}
+
+func (t *_RenderedHTML) trimSpaces() {
+	for t.hasSuffix(" ") {
+		t.Text = t.Text[:len(t.Text)-1]
+		t.Starts = t.Starts[:len(t.Starts)-1]
+		t.Ends = t.Ends[:len(t.Ends)-1]
+	}
+}
+
+func (t *_RenderedHTML) newLine(start int, end int) {
+	t.trimSpaces()
+	t.write("\n", start, end)
+}
+
+func (t *_RenderedHTML) breakLine(start int, end int) {
+	if len(t.Text) > 0 && !t.hasSuffix("\n") {
+		t.newLine(start, end)
+	}
+}
+
+func _HTMLToText(tokens []_HTMLToken) _RenderedHTML {
+	text := _RenderedHTML{}
+
+	skipping := ""
+	preformatted := 0
+	cells := 0
+
+	// The start of the label of a header table row ("<th>From</th>"), and
+	// whether each open blockquote is a quote
+	label := -1
+	quotes := []bool{}
+
+	for _, token := range tokens {
+		if len(skipping) > 0 {
+			if token.Type == _HTMLEndTag && token.Name == skipping {
+				skipping = ""
+			}
+
+			continue
+		}
+
+		switch token.Type {
+		case _HTMLText:
+			_WriteHTMLText(&text, token, preformatted > 0)
+		case _HTMLStartTag, _HTMLSelfClosingTag:
+			if token.Type == _HTMLStartTag && _HTMLSkippedElements[token.Name] {
+				skipping = token.Name
+				continue
+			}
+
+			switch {
+			case token.Name == "br":
+				text.newLine(token.Start, token.End)
+			case token.Name == "tr":
+				cells = 0
+				label = -1
+				text.breakLine(token.Start, token.End)
+			case token.Name == "td" || token.Name == "th":
+				// Header tables, as Thunderbird's, label each value with a
+				// header cell, with or without a colon
+				if token.Name == "td" && label >= 0 && cells == 1 && len(trimString(string(text.Text[label:]))) > 0 {
+					text.trimSpaces()
+
+					if !text.hasSuffix(":") {
+						text.write(":", token.Start, token.Start)
+					}
+				}
+
+				if cells > 0 && !text.hasSuffix(" ") && !text.hasSuffix("\n") {
+					text.write(" ", token.Start, token.End)
+				}
+
+				if token.Name == "th" && cells == 0 {
+					label = len(text.Text)
+				}
+
+				cells++
+			case token.Name == "img":
+				if alt := token.Attr("alt"); len(alt) > 0 {
+					text.write(alt, token.Start, token.End)
+				}
+			case token.Attr("id") == "divRplyFwdMsg" || strings.HasPrefix(token.Attr("id"), "divRplyFwdMsg"):
+				// Outlook separates the forwarded header block with an <hr>,
+				// which is rendered as this line in its plain text version
+				text.breakLine(token.Start, token.End)
+
+				if !text.hasSuffix(_OutlookSeparatorLine + "\n") {
+					text.write(_OutlookSeparatorLine+"\n", token.Start, token.End)
+				}
+			case _HTMLBlockElements[token.Name]:
+				text.breakLine(token.Start, token.End)
+			}
+
+			if token.Type == _HTMLStartTag && token.Name == "blockquote" {
+				quote := _IsHTMLQuote(token)
+				if quote {
+					text.Quote++
+				}
+
+				quotes = append(quotes, quote)
+			}
+
+			if token.Type == _HTMLStartTag && token.Name == "pre" {
+				preformatted++
+			}
+		case _HTMLEndTag:
+			if token.Name == "pre" && preformatted > 0 {
+				preformatted--
+			}
+
+			if token.Name == "p" {
+				text.breakLine(token.Start, token.End)
+				text.newLine(token.Start, token.End)
+			} else if _HTMLBlockElements[token.Name] {
+				text.breakLine(token.Start, token.End)
+			}
+
+			if token.Name == "blockquote" && len(quotes) > 0 {
+				if quotes[len(quotes)-1] {
+					text.Quote--
+				}
+
+				quotes = quotes[:len(quotes)-1]
+			}
+		}
+	}
+
+	return text
+}
+
+const _OutlookSeparatorLine = "________________________________"
+
+// _IsHTMLQuote tells whether a blockquote quotes an email, as those of Apple
+// Mail and Thunderbird (type="cite") and Gmail (class="gmail_quote") do
+func _IsHTMLQuote(token _HTMLToken) bool {
+	if strings.EqualFold(token.Attr("type"), "cite") {
+		return true
+	}
+
+	for _, class := range strings.Fields(token.Attr("class")) {
+		if class == "gmail_quote" {
+			return true
+		}
+	}
+
+	return false
+}
+
+func _WriteHTMLText(text *_RenderedHTML, token _HTMLToken, preformatted bool) {
+	raw := token.Raw
+
+	for i := 0; i < len(raw); {
+		start := token.Start + i
+
+		if raw[i] == '&' {
+			end := strings.IndexByte(raw[i:], ';')
+
+			if end > 1 && end <= 32 {
+				entity := raw[i : i+end+1]
+				decoded := html.UnescapeString(entity)
+
+				if decoded != entity {
+					decoded = strings.ReplaceAll(decoded, " ", " ")
+
+					if decoded == " " && !preformatted {
+						_WriteHTMLSpace(text, start, start+end+1, true)
+					} else {
+						text.write(decoded, start, start+end+1)
+					}
+
+					i += end + 1
+					continue
+				}
+			}
+		}
+
+		if _IsHTMLSpace(raw[i]) && !preformatted {
+			end := i
+			for end < len(raw) && _IsHTMLSpace(raw[end]) {
+				end++
+			}
+
+			_WriteHTMLSpace(text, start, token.Start+end, false)
+
+			i = end
+			continue
+		}
+
+		if raw[i] == '\r' {
+			i++
+			continue
+		}
+
+		text.write(raw[i:i+1], start, start+1)
+		i++
+	}
+}
+
+func _WriteHTMLSpace(text *_RenderedHTML, start int, end int, nonBreaking bool) {
+	if len(text.Text) == 0 || text.hasSuffix("\n") {
+		return
+	}
+
+	if !nonBreaking && text.hasSuffix(" ") {
+		return
+	}
+
+	text.write(" ", start, end)
+}
+
+var _SanitizedHTMLElements = map[string][]string{
+	"a":          {"href", "title"},
+	"b":          nil,
+	"blockquote": nil,
+	"br":         nil,
+	"code":       nil,
+	"div":        {"dir"},
+	"em":         nil,
+	"h1":         nil,
+	"h2":         nil,
+	"h3":         nil,
+	"h4":         nil,
+	"h5":         nil,
+	"h6":         nil,
+	"hr":         nil,
+	"i":          nil,
+	"img":        {"src", "alt", "title", "width", "height"},
+	"li":         nil,
+	"ol":         nil,
+	"p":          {"dir"},
+	"pre":        nil,
+	"s":          nil,
+	"span":       {"dir"},
+	"strong":     nil,
+	"sub":        nil,
+	"sup":        nil,
+	"table":      nil,
+	"tbody":      nil,
+	"td":         {"colspan", "rowspan"},
+	"tfoot":      nil,
+	"th":         {"colspan", "rowspan"},
+	"thead":      nil,
+	"tr":         nil,
+	"u":          nil,
+	"ul":         nil,
+}
+
+var _SanitizedURLSchemes = []string{
+	"http:",
+	"https:",
+	"mailto:",
+	"cid:",
+}
+
+func _SanitizeHTML(source string, tokens []_HTMLToken, start int, end int) string {
+	builder := strings.Builder{}
+	stack := []string{}
+
+	skipping := ""
+
+	for _, token := range tokens {
+		if token.End <= start || token.Start >= end {
+			continue
+		}
+
+		if len(skipping) > 0 {
+			if token.Type == _HTMLEndTag && token.Name == skipping {
+				skipping = ""
+			}
+
+			continue
+		}
+
+		switch token.Type {
+		case _HTMLText:
+			textStart := token.Start
+			if textStart < start {
+				textStart = start
+			}
+
+			textEnd := token.End
+			if textEnd > end {
+				textEnd = end
+			}
+
+			builder.WriteString(html.EscapeString(html.UnescapeString(source[textStart:textEnd])))
+		case _HTMLStartTag, _HTMLSelfClosingTag:
+			if _HTMLSkippedElements[token.Name] {
+				if token.Type == _HTMLStartTag {
+					skipping = token.Name
+				}
+
+				continue
+			}
+
+			allowed, ok := _SanitizedHTMLElements[token.Name]
+			if !ok {
+				continue
+			}
+
+			builder.WriteString("<" + token.Name)
+
+			for _, attr := range token.Attrs {
+				if !_ContainsString(allowed, attr.Name) {
+					continue
+				}
+
+				if (attr.Name == "href" || attr.Name == "src") && !_IsSanitizedURL(attr.Value) {
+					continue
+				}
+
+				builder.WriteString(" " + attr.Name + "=\"" + html.EscapeString(attr.Value) + "\"")
+			}
+
+			builder.WriteString(">")
+
+			if token.Type == _HTMLStartTag {
+				stack = append(stack, token.Name)
+			}
+		case _HTMLEndTag:
+			for i := len(stack) - 1; i >= 0; i-- {
+				if stack[i] == token.Name {
+					for j := len(stack) - 1; j >= i; j-- {
+						builder.WriteString("</" + stack[j] + ">")
+					}
+
+					stack = stack[:i]
+					break
+				}
+			}
+		}
+	}
+
+	for i := len(stack) - 1; i >= 0; i-- {
+		builder.WriteString("</" + stack[i] + ">")
+	}
+
+	return trimString(builder.String())
+}
+
+// Widen a range of text to the opening tags right before it and the closing
+// tags right after it, so the elements wrapping the text are kept
+func _ExpandHTMLRange(tokens []_HTMLToken, start int, end int) (int, int) {
+	for i := len(tokens) - 1; i >= 0; i-- {
+		token := tokens[i]
+
+		if token.End > start {
+			continue
+		}
+
+		if token.End < start || !(token.Type == _HTMLStartTag || _IsHTMLWhitespace(token)) {
+			break
+		}
+
+		start = token.Start
+	}
+
+	for _, token := range tokens {
+		if token.Start < end {
+			continue
+		}
+
+		if token.Start > end || !(token.Type == _HTMLEndTag || _IsHTMLWhitespace(token)) {
+			break
+		}
+
+		end = token.End
+	}
+
+	return start, end
+}
+
+func _IsHTMLWhitespace(token _HTMLToken) bool {
+	return token.Type == _HTMLText && len(strings.TrimFunc(token.Raw, func(r rune) bool { return r < 0x80 && _IsHTMLSpace(byte(r)) })) == 0
+}
+
+func _IsSanitizedURL(url string) bool {
+	url = strings.ToLower(trimString(url))
+
+	for _, scheme := range _SanitizedURLSchemes {
+		if strings.HasPrefix(url, scheme) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func _ContainsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ReadHTML parses an HTML email body: it is rendered to text as in its plain
+// text version, with the header block of Outlook (divRplyFwdMsg) separated,
+// the quotes of Gmail (gmail_quote) and Apple Mail and Thunderbird
+// (blockquote type="cite") quoted with ">", and the cells of header tables
+// read as headers, then parsed like Read; Email.BodyHTML additionally holds
+// the sanitized HTML of the original body.
+func ReadHTML(body string, subject string) ReadResult {
+	return _DefaultParser.ReadHTML(body, subject)
+}
+
+// ReadHTML is like the package-level ReadHTML, using the parser's patterns.
+func (parser *Parser) ReadHTML(body string, subject string) ReadResult {
+	tokens := _TokenizeHTML(body)
+	text := _HTMLToText(tokens)
+
+	result, _ := parser._Read(string(text.Text), subject, true)
+
+	// The span of the body, after the separator and header block, rather
+	// than the first text equal to it, which may be in the message
+	if span := result.Spans.Body; len(result.Email.Body) > 0 && span.End > span.Start {
+		start, end := _ExpandHTMLRange(tokens, text.Starts[span.Start], text.Ends[span.End-1])
+
+		result.Email.BodyHTML = _SanitizeHTML(body, tokens, start, end)
+	}
+
+	result.Spans = nil
+
+	return result
+}
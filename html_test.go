@@ -0,0 +1,144 @@
+package emailforwardparser
+
+import (
+	"log"
+	"os"
+	"strings"
+	"testing"
+)
+
+func _ReadHTMLFixture(emailFile string) string {
+	emailBytes, err := os.ReadFile("./fixtures/" + emailFile + ".html")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return string(emailBytes)
+}
+
+func TestReadHTML(t *testing.T) {
+	for _, entry := range []string{
+		"apple_mail_en_body",
+		"gmail_en_body",
+		"new_outlook_2019_en_body",
+		"thunderbird_en_body",
+	} {
+		result := ReadHTML(_ReadHTMLFixture(entry), "")
+
+		_TestEmail(t, result, entry, false, false, false, false, false)
+
+		if !strings.Contains(result.Email.BodyHTML, "Aenean quis diam urna.") {
+			t.Error(entry, "result.Email.BodyHTML does not contain the original body", result.Email.BodyHTML)
+		}
+
+		for _, unsafe := range []string{"<script", "onclick", "javascript:", "style=", "From:"} {
+			if strings.Contains(result.Email.BodyHTML, unsafe) {
+				t.Error(entry, "result.Email.BodyHTML contains", unsafe, result.Email.BodyHTML)
+			}
+		}
+	}
+}
+
+func TestHTMLToText(t *testing.T) {
+	text := _HTMLToText(_TokenizeHTML("<p>A&nbsp;&amp;  B</p><table><tr><td><b>From:</b></td><td>C</td></tr></table><style>p{}</style>D<br/>E"))
+
+	if string(text.Text) != "A & B\n\nFrom: C\nD\nE" {
+		t.Errorf("unexpected text %q", text.Text)
+	}
+}
+
+func TestSanitizeHTML(t *testing.T) {
+	source := `<div onclick="x()"><a href="javascript:x()">A</a> <a href="https://acme.com" target="_blank">B</a><img src="data:x"></div><span>C`
+	sanitized := _SanitizeHTML(source, _TokenizeHTML(source), 0, len(source))
+
+	if sanitized != `<div><a>A</a> <a href="https://acme.com">B</a><img></div><span>C</span>` {
+		t.Error("unexpected sanitized html", sanitized)
+	}
+}
+
+func TestReadHTMLBodyAfterSeparator(t *testing.T) {
+	body := `<div><b>Thanks</b></div>` +
+		`<div>---------- Forwarded message ---------<br>From: John Doe &lt;john.doe@acme.com&gt;<br>Date: Wed, Oct 27, 2021 at 9:31 AM<br>Subject: Integer consequat non purus<br>To: Bessie Berry &lt;bessie.berry@acme.com&gt;<br></div><br>` +
+		`<div><i>Thanks</i></div>`
+
+	result := ReadHTML(body, "Fwd: Integer consequat non purus")
+
+	if result.Email.Body != "Thanks" || result.Email.BodyHTML != "<div><i>Thanks</i></div>" {
+		t.Errorf("unexpected body %q, html %q", result.Email.Body, result.Email.BodyHTML)
+	}
+}
+
+func TestTokenizeHTMLInvalidUTF8(t *testing.T) {
+	// Lowercasing turns each invalid byte into a 3-byte rune, moving the
+	// closing tag
+	ReadHTML("<style>\xff\xff\xff\xff</style", "Fwd: x")
+
+	tokens := _TokenizeHTML("<STYLE>\xff\xff</StYlE>x")
+
+	if len(tokens) != 4 || tokens[1].Raw != "\xff\xff" || tokens[2].Name != "style" || tokens[3].Raw != "x" {
+		t.Errorf("unexpected tokens %+v", tokens)
+	}
+}
+
+func TestReadHTMLGmailQuote(t *testing.T) {
+	body := `<div dir="ltr">That's true!</div><br><div class="gmail_quote"><div dir="ltr" class="gmail_attr">On Wed, Oct 27, 2021 at 9:31 AM John Doe &lt;<a href="mailto:john.doe@acme.com">john.doe@acme.com</a>&gt; wrote:<br></div>` +
+		`<blockquote class="gmail_quote" style="margin:0px 0px 0px 0.8ex"><div dir="ltr">Aenean quis diam urna.<br><br>Praesent ac ligula orci.</div></blockquote></div>`
+
+	text := _HTMLToText(_TokenizeHTML(body))
+
+	if !strings.HasSuffix(string(text.Text), "wrote:\n> Aenean quis diam urna.\n> \n> Praesent ac ligula orci.\n") {
+		t.Errorf("unexpected text %q", text.Text)
+	}
+
+	if result := ReadHTML(body, "Re: Integer consequat non purus"); result.Kind != KindReplyWithQuote || result.Forwarded {
+		t.Error("unexpected kind", result.Kind)
+	}
+}
+
+func TestReadHTMLCiteQuote(t *testing.T) {
+	body := `<div>Thanks</div><blockquote type="cite"><div>Begin forwarded message:</div><br>` +
+		`<div><b>From: </b>John Doe &lt;john.doe@acme.com&gt;</div><div><b>Subject: </b>Integer consequat non purus</div><div><b>Date: </b>25 October 2021 at 11:17:21 EEST</div><div><b>To: </b>bessie.berry@acme.com</div><br>` +
+		`<div>Aenean quis diam urna.</div><blockquote type="CITE"><div>Praesent ac ligula orci.</div></blockquote><blockquote><div>Sed nec facilisis tellus.</div></blockquote></blockquote>`
+
+	text := _HTMLToText(_TokenizeHTML(body))
+
+	if !strings.HasSuffix(string(text.Text), "> Aenean quis diam urna.\n>> Praesent ac ligula orci.\n> Sed nec facilisis tellus.\n") {
+		t.Errorf("unexpected text %q", text.Text)
+	}
+
+	result := ReadHTML(body, "")
+
+	if !result.Forwarded || result.Email.From.Address != "john.doe@acme.com" || result.Email.Subject != "Integer consequat non purus" {
+		t.Error("the quoted email was not read", result)
+	}
+
+	if !strings.HasPrefix(result.Email.Body, "Aenean quis diam urna.") || strings.Contains(result.Email.Body, "> ") {
+		t.Errorf("unexpected body %q", result.Email.Body)
+	}
+}
+
+func TestReadHTMLHeaderTable(t *testing.T) {
+	body := `<div>Thanks</div><div>-------- Forwarded Message --------<table>` +
+		`<tr><th>Subject</th><td>Integer consequat non purus</td></tr>` +
+		`<tr><th>Date</th><td>Wed, 3 Nov 2021 15:51:30 +0100</td></tr>` +
+		`<tr><th>From: </th><td>John Doe &lt;john.doe@acme.com&gt;</td></tr>` +
+		`<tr><th> To </th><td>bessie.berry@acme.com</td></tr>` +
+		`</table><br><p>Aenean quis diam urna.</p></div>`
+
+	text := _HTMLToText(_TokenizeHTML(body))
+
+	if !strings.Contains(string(text.Text), "\nSubject: Integer consequat non purus\nDate: Wed, 3 Nov 2021 15:51:30 +0100\nFrom: John Doe <john.doe@acme.com>\nTo: bessie.berry@acme.com\n") {
+		t.Errorf("unexpected text %q", text.Text)
+	}
+
+	result := ReadHTML(body, "")
+
+	if result.Email.From.Address != "john.doe@acme.com" || result.Email.Subject != "Integer consequat non purus" || len(result.Email.To) != 1 || result.Email.Body != "Aenean quis diam urna." {
+		t.Error("the header table was not read", result)
+	}
+
+	// Headings of data tables are not labels
+	if text := _HTMLToText(_TokenizeHTML(`<table><tr><th>Name</th><th>Size</th></tr><tr><td>Budget</td><td>4</td></tr></table>`)); string(text.Text) != "Name Size\nBudget 4\n" {
+		t.Errorf("unexpected text %q", text.Text)
+	}
+}
@@ -0,0 +1,91 @@
+package emailforwardparser
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+//go:embed schema.json
+var _Schema []byte
+
+// JSONSchema returns the JSON Schema of ReadResult as encoded by
+// encoding/json. Its core (forwarded, message, email.body, email.from,
+// email.to, email.cc, email.subject and email.date) is the output of the
+// JavaScript library, so results can be exchanged with it both ways.
+func JSONSchema() []byte {
+	return append([]byte{}, _Schema...)
+}
+
+type _ReadResultEmailJSON ReadResultEmail
+
+// MarshalJSON encodes the email, leaving dateTime out when Date could not be
+// parsed.
+func (email ReadResultEmail) MarshalJSON() ([]byte, error) {
+	var dateTime *time.Time
+
+	if !email.DateTime.IsZero() {
+		dateTime = &email.DateTime
+	}
+
+	return json.Marshal(struct {
+		_ReadResultEmailJSON
+		DateTime *time.Time `json:"dateTime,omitempty"`
+	}{_ReadResultEmailJSON(email), dateTime})
+}
+
+// UnmarshalJSON decodes an email encoded by MarshalJSON or by the JavaScript
+// library, whose missing and null fields are left empty.
+func (email *ReadResultEmail) UnmarshalJSON(data []byte) error {
+	decoded := struct {
+		*_ReadResultEmailJSON
+		DateTime *time.Time `json:"dateTime"`
+	}{_ReadResultEmailJSON: (*_ReadResultEmailJSON)(email)}
+
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	email.DateTime = time.Time{}
+
+	if decoded.DateTime != nil {
+		email.DateTime = *decoded.DateTime
+	}
+
+	return nil
+}
+
+// MarshalText encodes the confidence as its name, such as "high".
+func (confidence Confidence) MarshalText() ([]byte, error) {
+	return []byte(confidence.String()), nil
+}
+
+// UnmarshalText decodes a confidence encoded by MarshalText.
+func (confidence *Confidence) UnmarshalText(text []byte) error {
+	for _, candidate := range []Confidence{ConfidenceNone, ConfidenceLow, ConfidenceMedium, ConfidenceHigh} {
+		if candidate.String() == string(text) {
+			*confidence = candidate
+			return nil
+		}
+	}
+
+	return fmt.Errorf("emailforwardparser: unknown confidence %q", text)
+}
+
+// MarshalText encodes the kind as its name, such as "reply-with-quote".
+func (kind Kind) MarshalText() ([]byte, error) {
+	return []byte(kind.String()), nil
+}
+
+// UnmarshalText decodes a kind encoded by MarshalText.
+func (kind *Kind) UnmarshalText(text []byte) error {
+	for _, candidate := range []Kind{KindUnknown, KindForward, KindReply, KindReplyWithQuote} {
+		if candidate.String() == string(text) {
+			*kind = candidate
+			return nil
+		}
+	}
+
+	return fmt.Errorf("emailforwardparser: unknown kind %q", text)
+}
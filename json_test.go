@@ -0,0 +1,215 @@
+package emailforwardparser
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestJSONRoundTrip(t *testing.T) {
+	for _, name := range []string{"gmail_en_body", "outlook_2019_en_body", "yahoo_de_body", "apple_mail_de_body_variant_16"} {
+		email, _ := _Read(name, "")
+
+		result := ReadWithSpans(email, "")
+
+		data, err := json.Marshal(result)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		decoded := ReadResult{}
+
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatal(name, err)
+		}
+
+		if !decoded.Email.DateTime.Equal(result.Email.DateTime) {
+			t.Error(name, "unexpected date", decoded.Email.DateTime, result.Email.DateTime)
+		}
+
+		decoded.Email.DateTime = result.Email.DateTime
+
+		if !reflect.DeepEqual(decoded, result) {
+			t.Errorf("%s: the result does not round-trip:\n%+v\n%+v", name, decoded, result)
+		}
+	}
+}
+
+func TestJSONKeys(t *testing.T) {
+	data, err := json.Marshal(_ReadAndParse("gmail_en_body", ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, key := range []string{
+		`"forwarded":true`,
+		`"message":""`,
+		`"from":{"name":"John Doe","address":"john.doe@acme.com"}`,
+		`"to":[{"name":"","address":"bessie.berry@acme.com"}]`,
+		`"subject":"Integer consequat non purus"`,
+		`"dateTime":"2021-10-27T09:31:00Z"`,
+		`"confidence":"high"`,
+	} {
+		if !strings.Contains(string(data), key) {
+			t.Error("missing", key, "in", string(data))
+		}
+	}
+
+	if strings.Contains(string(data), `"spans"`) {
+		t.Error("unexpected spans")
+	}
+
+	data, _ = json.Marshal(Read(_TestMessage, ""))
+
+	if strings.Contains(string(data), `"dateTime"`) {
+		t.Error("unexpected date", string(data))
+	}
+}
+
+// The output of the JavaScript library
+func TestJSONUnmarshalJavaScript(t *testing.T) {
+	data := `{
+		"forwarded": true,
+		"message": null,
+		"email": {
+			"body": "Aenean quis diam urna.",
+			"from": {"address": "john.doe@acme.com", "name": "John Doe"},
+			"to": [{"address": "bessie.berry@acme.com", "name": null}],
+			"cc": [],
+			"subject": "Integer consequat non purus",
+			"date": "Wed, Oct 27, 2021 at 9:31 AM"
+		}
+	}`
+
+	result := ReadResult{}
+
+	if err := json.Unmarshal([]byte(data), &result); err != nil {
+		t.Fatal(err)
+	}
+
+	if !result.Forwarded || result.Message != "" || result.Email.From.Name != _TestFromName || result.Email.To[0].Address != _TestToAddress1 || len(result.Email.CC) != 0 || !result.Email.DateTime.IsZero() {
+		t.Errorf("unexpected result %+v", result)
+	}
+
+	if err := json.Unmarshal([]byte(`{"matches": {"from": {"confidence": "certain"}}}`), &result); err == nil {
+		t.Error("expected an error for an unknown confidence")
+	}
+}
+
+func TestJSONSchema(t *testing.T) {
+	schema := map[string]interface{}{}
+
+	if err := json.Unmarshal(JSONSchema(), &schema); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"gmail_en_body", "outlook_2019_en_body", "thunderbird_en_body_variant_16"} {
+		email, _ := _Read(name, "")
+
+		for _, result := range []ReadResult{ReadWithSpans(email, ""), Read(_TestMessage, "")} {
+			data, err := json.Marshal(result)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			value := map[string]interface{}{}
+
+			if err := json.Unmarshal(data, &value); err != nil {
+				t.Fatal(err)
+			}
+
+			for _, err := range _ValidateSchema(schema, schema, value, "") {
+				t.Error(name, err)
+			}
+		}
+	}
+}
+
+// _ValidateSchema checks value against the parts of JSON Schema used by
+// schema.json
+func _ValidateSchema(root map[string]interface{}, schema map[string]interface{}, value interface{}, path string) []string {
+	if ref, ok := schema["$ref"].(string); ok {
+		defs := root["$defs"].(map[string]interface{})
+
+		return _ValidateSchema(root, defs[strings.TrimPrefix(ref, "#/$defs/")].(map[string]interface{}), value, path)
+	}
+
+	if enum, ok := schema["enum"].([]interface{}); ok {
+		for _, candidate := range enum {
+			if candidate == value {
+				return nil
+			}
+		}
+
+		return []string{path + ": not in enum"}
+	}
+
+	types := []interface{}{schema["type"]}
+
+	if list, ok := schema["type"].([]interface{}); ok {
+		types = list
+	}
+
+	valueType := map[bool]string{true: "null"}[value == nil]
+
+	switch value := value.(type) {
+	case bool:
+		valueType = "boolean"
+	case string:
+		valueType = "string"
+	case float64:
+		valueType = "number"
+
+		if value == float64(int(value)) {
+			valueType = "integer"
+		}
+	case []interface{}:
+		valueType = "array"
+	case map[string]interface{}:
+		valueType = "object"
+	}
+
+	matched := false
+
+	for _, candidate := range types {
+		if candidate == valueType || (candidate == "number" && valueType == "integer") {
+			matched = true
+		}
+	}
+
+	if !matched {
+		return []string{path + ": unexpected type " + valueType}
+	}
+
+	errs := []string{}
+
+	switch value := value.(type) {
+	case []interface{}:
+		for _, item := range value {
+			errs = append(errs, _ValidateSchema(root, schema["items"].(map[string]interface{}), item, path+"[]")...)
+		}
+	case map[string]interface{}:
+		properties, _ := schema["properties"].(map[string]interface{})
+
+		for key, property := range value {
+			propertySchema, ok := properties[key].(map[string]interface{})
+			if !ok {
+				errs = append(errs, path+"."+key+": not in the schema")
+				continue
+			}
+
+			errs = append(errs, _ValidateSchema(root, propertySchema, property, path+"."+key)...)
+		}
+
+		required, _ := schema["required"].([]interface{})
+
+		for _, key := range required {
+			if _, ok := value[key.(string)]; !ok {
+				errs = append(errs, path+"."+key.(string)+": missing")
+			}
+		}
+	}
+
+	return errs
+}
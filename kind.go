@@ -0,0 +1,120 @@
+package emailforwardparser
+
+import (
+	regexp "github.com/wasilibs/go-re2"
+)
+
+// Kind tells whether a message forwards an email or replies to one, see
+// ReadResult.Kind.
+type Kind int
+
+const (
+	// KindUnknown is a message without a forward or reply prefix in its
+	// subject, and without a forward separator in its body: either a new
+	// message, or one quoting an email as both Outlook 2019 forwards and
+	// replies do ("On …, "John Doe" <john.doe@acme.com> wrote:"). It is
+	// also a message with a prefix that forwards in a locale and replies in
+	// another ("VS:"), when the headers of its body do not tell which.
+	KindUnknown Kind = iota
+	// KindForward is a forward, told by the prefix of its subject ("Fwd:")
+	// or by its separator.
+	KindForward
+	// KindReply is a reply ("Re:") without the email it replies to.
+	KindReply
+	// KindReplyWithQuote is a reply quoting the email it replies to, after a
+	// separator or header block, or in quoted lines ("> ").
+	KindReplyWithQuote
+)
+
+func (kind Kind) String() string {
+	switch kind {
+	case KindForward:
+		return "forward"
+	case KindReply:
+		return "reply"
+	case KindReplyWithQuote:
+		return "reply-with-quote"
+	}
+
+	return "unknown"
+}
+
+func (parser *Parser) _ParseReplySubject(subject string) *regexp.Regexp {
+	if len(subject) == 0 {
+		return nil
+	}
+
+	parser.trace._SetStage("Subject")
+
+	_, pattern := parser._MatchPatterns(parser.patterns.ReplyPrefixes, subject)
+
+	return pattern
+}
+
+// _PrefixKind tells whether a prefix of the subject that both forwards and
+// replies, such as "VS:" forwarding in Danish and replying in Finnish, is a
+// forward or a reply, by the locales of the separator and headers found in
+// the body; it is KindUnknown when they are written in both or neither
+func (parser *Parser) _PrefixKind(subjectPattern *regexp.Regexp, replyPattern *regexp.Regexp, bodyResult _ParseBodyResult, email _ParseOriginalEmailResult) Kind {
+	forward, reply := false, false
+
+	for _, pattern := range []*regexp.Regexp{bodyResult.Separator, email.Headers.From, email.Headers.Subject, email.Headers.Date} {
+		forward = forward || parser._SharesLocale(pattern, subjectPattern)
+		reply = reply || parser._SharesLocale(pattern, replyPattern)
+	}
+
+	switch {
+	case forward && !reply:
+		return KindForward
+	case reply && !forward:
+		return KindReply
+	}
+
+	return KindUnknown
+}
+
+// _SharesLocale tells whether a and b were written in a common locale
+func (parser *Parser) _SharesLocale(a *regexp.Regexp, b *regexp.Regexp) bool {
+	for _, source := range parser.patterns.Sources[a] {
+		if source.Locales == nil {
+			return len(parser.patterns.Sources[b]) > 0
+		}
+
+		for _, locale := range source.Locales {
+			if parser._WrittenIn(b, locale) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// _Kind classifies a read: the prefix of the subject comes first, then the
+// separator found in the body, as Outlook 2019 introduces forwards and
+// replies alike
+func (parser *Parser) _Kind(body string, subjectPattern *regexp.Regexp, replyPattern *regexp.Regexp, forwarded bool, separator *regexp.Regexp) Kind {
+	if subjectPattern != nil {
+		return KindForward
+	}
+
+	if replyPattern != nil {
+		if _Quote.MatchString(body) {
+			return KindReplyWithQuote
+		}
+
+		// New Outlook 2019 quotes the email after its header block, as it
+		// forwards it
+		if len(parser._ParseBody(preprocessString(body), true, nil).Email) > 0 {
+			return KindReplyWithQuote
+		}
+
+		return KindReply
+	}
+
+	if forwarded && !parser.patterns.Replies[separator] {
+		return KindForward
+	}
+
+	return KindUnknown
+}
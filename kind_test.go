@@ -0,0 +1,76 @@
+package emailforwardparser
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestReadKind(t *testing.T) {
+	gmail, _ := _Read("gmail_en_body", "")
+	outlook, _ := _Read("outlook_2019_en_body", "")
+	outlookLive, _ := _Read("outlook_live_en_body_variant_4", "")
+	newOutlook, _ := _Read("new_outlook_2019_en_body_variant_4", "")
+	newOutlookDanish, _ := _Read("new_outlook_2019_da_body", "")
+	newOutlookFinnish, _ := _Read("new_outlook_2019_fi_body", "")
+
+	for _, test := range []struct {
+		Name    string
+		Body    string
+		Subject string
+		Kind    Kind
+	}{
+		{"forward", gmail, "", KindForward},
+		{"forward with a subject", gmail, "Fwd: " + _TestSubject, KindForward},
+		{"forward without separator", _TestMessage, "WG: " + _TestSubject, KindForward},
+		{"message", _TestMessage, "", KindUnknown},
+		{"message with a subject", _TestMessage, _TestSubject, KindUnknown},
+		{"reply", _TestMessage, "Re: " + _TestSubject, KindReply},
+		{"localized reply", _TestMessage, "AW: " + _TestSubject, KindReply},
+		{"Hungarian reply", _TestMessage, "VÁ: " + _TestSubject, KindReply},
+		{"lowercase reply", _TestMessage, "re: " + _TestSubject, KindReply},
+		{"lowercase localized reply", _TestMessage, "Aw: " + _TestSubject, KindReply},
+		{"lowercase Swedish reply", _TestMessage, "Sv: " + _TestSubject, KindReply},
+		{"single-letter reply", _TestMessage, "R: " + _TestSubject, KindReply},
+		{"lowercase single letter", _TestMessage, "r: " + _TestSubject, KindUnknown},
+		{"Danish forward", newOutlookDanish, "VS: " + _TestSubject, KindForward},
+		{"Finnish reply", newOutlookFinnish, "VS: " + _TestSubject, KindReplyWithQuote},
+		{"Danish forward or Finnish reply", _TestMessage, "VS: " + _TestSubject, KindUnknown},
+		{"reply with quoted lines", _TestMessage + "\n\n> " + _TestBody, "Re: " + _TestSubject, KindReplyWithQuote},
+		{"reply with a separator", outlookLive, "RE: " + _TestSubject, KindReplyWithQuote},
+		{"reply with a header block", newOutlook, "Re: " + _TestSubject, KindReplyWithQuote},
+		{"forward or reply", outlook, "", KindUnknown},
+		{"forward of a reply", gmail, "Fwd: Re: " + _TestSubject, KindForward},
+		{"reply to a forward", _TestMessage, "Re: Fwd: " + _TestSubject, KindReply},
+	} {
+		result := Read(test.Body, test.Subject)
+
+		if result.Kind != test.Kind {
+			t.Errorf("%s: unexpected kind %v, expected %v", test.Name, result.Kind, test.Kind)
+		}
+
+		if test.Kind >= KindReply && result.Forwarded {
+			t.Errorf("%s: a reply was read as a forward", test.Name)
+		}
+	}
+}
+
+func TestKindJSON(t *testing.T) {
+	for _, kind := range []Kind{KindUnknown, KindForward, KindReply, KindReplyWithQuote} {
+		data, err := json.Marshal(kind)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		decoded := Kind(-1)
+
+		if err := json.Unmarshal(data, &decoded); err != nil || decoded != kind {
+			t.Error("unexpected kind", string(data), decoded, err)
+		}
+	}
+
+	kind := KindForward
+
+	if err := json.Unmarshal([]byte(`"quote"`), &kind); err == nil {
+		t.Error("an unknown kind was decoded")
+	}
+}
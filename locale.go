@@ -0,0 +1,274 @@
+package emailforwardparser
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"strconv"
+	"strings"
+
+	regexp "github.com/wasilibs/go-re2"
+)
+
+//go:embed locales/*.json
+var _LocaleFiles embed.FS
+
+// A locale file holds, for each field of a PatternSet (in snake case), the
+// words a client writes in that locale:
+//
+//	{
+//		"locale": "de",
+//		"subject": [{"prefix": "WG", "clients": ["Outlook Live / 365"]}],
+//		"reply_subject": [{"prefix": "AW", "clients": ["Outlook Live / 365"]}],
+//		"separator": [{"phrase": "Weitergeleitete Nachricht", "dashes": [5, 10], "clients": ["Thunderbird"]}],
+//		"original_from": [{"label": "Von", "clients": ["Apple Mail", "Gmail"]}]
+//	}
+//
+// Separator phrases end with a colon, or are framed by dashes, and signature
+// phrases start a line; signature entries may have no clients, as
+// disclaimers are not written by a client. Header labels
+// are followed by a colon, unless another one is given, and may be written
+// in bold ("*From:*"). Entries that do not follow the usual layout of their
+// field give a regular expression instead, in "expr". A file without a locale holds the
+// patterns clients write in all locales.
+type _LocaleEntry struct {
+	Prefix string `json:"prefix"`
+	Phrase string `json:"phrase"`
+	Dashes []int  `json:"dashes"`
+	Label  string `json:"label"`
+	Colon  string `json:"colon"`
+	Bold   bool   `json:"bold"`
+	Expr   string `json:"expr"`
+
+	// A separator expr with the "date", "from_name" and "from_address" named
+	// groups, also used as a SeparatorWithInformation pattern
+	Information bool `json:"information"`
+
+	Clients []string `json:"clients"`
+}
+
+// The layout of the header fields, around the optional "*" of bold headers
+// and their label
+var _LocaleHeaderLayouts = map[string]string{
+	"OriginalSubject":    `(?im)^%[1]s%[2]s(.+)`,
+	"OriginalSubjectLax": `(?i)%[1]s%[2]s(.+)`,
+	"OriginalFrom":       `(?m)^(%[1]s\s*%[2]s(.+))$`,
+	"OriginalFromLax":    `(%[1]s\s*%[2]s(.+?)\s?\n?\s*[\[|<](.+?)[\]|>])`,
+	"OriginalTo":         `(?m)^%[1]s\s*%[2]s(.+)$`,
+	"OriginalToLax":      `(?m)%[1]s\s*%[2]s(.+)$`,
+	"OriginalReplyTo":    `(?m)^%[1]s\s*%[2]s(.+)$`,
+	"OriginalCC":         `(?m)^%[1]s\s*%[2]s(.+)$`,
+	"OriginalCCLax":      `(?m)%[1]s\s*%[2]s(.+)$`,
+	"OriginalBCC":        `(?m)^%[1]s\s*%[2]s(.+)$`,
+	"OriginalDate":       `(?m)^%[1]s\s*%[2]s(.+)$`,
+	"OriginalDateLax":    `(?m)%[1]s\s*%[2]s(.+)$`,
+	"OriginalMessageID":  `(?im)^%[1]s\s*%[2]s(.+)$`,
+	"OriginalInReplyTo":  `(?im)^%[1]s\s*%[2]s(.+)$`,
+	"OriginalReferences": `(?im)^%[1]s\s*%[2]s(.+)$`,
+}
+
+var _LocaleNamedGroup = regexp.MustCompile(`\(\?P<\w+>`)
+
+// ParseLocale returns the patterns of a locale file.
+func ParseLocale(data []byte) (PatternSet, error) {
+	fields := map[string]json.RawMessage{}
+
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return PatternSet{}, fmt.Errorf("emailforwardparser: invalid locale file: %w", err)
+	}
+
+	locale := ""
+
+	if raw, ok := fields["locale"]; ok {
+		if err := json.Unmarshal(raw, &locale); err != nil {
+			return PatternSet{}, fmt.Errorf("emailforwardparser: invalid locale file: %w", err)
+		}
+
+		delete(fields, "locale")
+	}
+
+	set := PatternSet{}
+	setFields := set._Fields()
+
+	for key := range fields {
+		if _, ok := _LocaleFields[key]; !ok {
+			return PatternSet{}, fmt.Errorf("emailforwardparser: invalid locale %q: unknown field %q", locale, key)
+		}
+	}
+
+	for _, field := range setFields {
+		raw, ok := fields[_LocaleFieldKey(field.Name)]
+		if !ok {
+			continue
+		}
+
+		decoder := json.NewDecoder(bytes.NewReader(raw))
+		decoder.DisallowUnknownFields()
+
+		entries := []_LocaleEntry{}
+
+		if err := decoder.Decode(&entries); err != nil {
+			return PatternSet{}, fmt.Errorf("emailforwardparser: invalid locale %q: %s: %w", locale, field.Name, err)
+		}
+
+		for _, entry := range entries {
+			expr, err := _LocaleEntryExpr(field.Name, entry)
+			if err != nil {
+				return PatternSet{}, fmt.Errorf("emailforwardparser: invalid locale %q: %s: %w", locale, field.Name, err)
+			}
+
+			source := _FormatLocaleSource(entry.Clients, locale)
+
+			if entry.Information {
+				set.SeparatorWithInformation = _MergePattern(set.SeparatorWithInformation, Pattern{expr, source})
+
+				expr = _LocaleNamedGroup.ReplaceAllString(expr, "(?:")
+			}
+
+			*field.Patterns = _MergePattern(*field.Patterns, Pattern{expr, source})
+		}
+	}
+
+	return set, nil
+}
+
+// LoadLocales returns the patterns of the locale files (*.json) at the root
+// of fsys, in the order of their names. The patterns of the default set are
+// loaded from the locale files embedded in the package.
+func LoadLocales(fsys fs.FS) (PatternSet, error) {
+	names, err := fs.Glob(fsys, "*.json")
+	if err != nil {
+		return PatternSet{}, err
+	}
+
+	set := PatternSet{}
+
+	for _, name := range names {
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return PatternSet{}, err
+		}
+
+		locale, err := ParseLocale(data)
+		if err != nil {
+			return PatternSet{}, fmt.Errorf("%w (%s)", err, name)
+		}
+
+		set = set.Extend(locale)
+	}
+
+	return set, nil
+}
+
+func _LocaleEntryExpr(field string, entry _LocaleEntry) (string, error) {
+	// Disclaimers are not written by a client
+	if len(entry.Clients) == 0 && field != "Signature" {
+		return "", fmt.Errorf("entry without clients")
+	}
+
+	if entry.Information && (field != "Separator" || len(entry.Expr) == 0) {
+		return "", fmt.Errorf("information is only allowed on separator exprs")
+	}
+
+	if len(entry.Expr) > 0 {
+		return entry.Expr, nil
+	}
+
+	switch {
+	case (field == "Subject" || field == "ReplySubject") && len(entry.Prefix) > 0:
+		return `(?m)^` + regexp.QuoteMeta(entry.Prefix) + `:(.*)`, nil
+
+	case field == "Signature" && len(entry.Phrase) > 0:
+		return `(?m)^[ \t]*` + regexp.QuoteMeta(entry.Phrase), nil
+
+	case field == "Separator" && len(entry.Phrase) > 0:
+		phrase := regexp.QuoteMeta(entry.Phrase)
+
+		switch len(entry.Dashes) {
+		case 0:
+			return `(?m)^>?\s*` + phrase + `\s?:`, nil
+		case 1:
+			dashes := `-{` + strconv.Itoa(entry.Dashes[0]) + `}`
+
+			return `(?m)^\s*` + dashes + ` ` + phrase + ` ` + dashes + `\s*`, nil
+		case 2:
+			dashes := `-{` + strconv.Itoa(entry.Dashes[0]) + `,` + strconv.Itoa(entry.Dashes[1]) + `}`
+
+			return `(?m)^\s*` + dashes + ` ` + phrase + ` ` + dashes + `\s*`, nil
+		}
+
+		return "", fmt.Errorf("dashes must hold one or two counts")
+	}
+
+	if layout, ok := _LocaleHeaderLayouts[field]; ok && len(entry.Label) > 0 {
+		colon := `\s?:`
+
+		if len(entry.Colon) > 0 {
+			colon = regexp.QuoteMeta(entry.Colon)
+		}
+
+		if entry.Bold {
+			return fmt.Sprintf(layout, `\*?`, regexp.QuoteMeta(entry.Label)+colon+`\*?`), nil
+		}
+
+		return fmt.Sprintf(layout, "", regexp.QuoteMeta(entry.Label)+colon), nil
+	}
+
+	switch field {
+	case "Subject", "ReplySubject":
+		return "", fmt.Errorf("entry without a prefix or expr")
+	case "Separator", "Signature":
+		return "", fmt.Errorf("entry without a phrase or expr")
+	}
+
+	return "", fmt.Errorf("entry without a label or expr")
+}
+
+func _FormatLocaleSource(clients []string, locale string) string {
+	if len(locale) == 0 {
+		locale = "all locales"
+	}
+
+	sources := make([]string, 0, len(clients))
+
+	for _, client := range clients {
+		sources = append(sources, client+" ("+locale+")")
+	}
+
+	return strings.Join(sources, ", ")
+}
+
+// The keys of the PatternSet fields in locale files
+var _LocaleFields = map[string]string{
+	"subject":              "Subject",
+	"reply_subject":        "ReplySubject",
+	"separator":            "Separator",
+	"original_subject":     "OriginalSubject",
+	"original_subject_lax": "OriginalSubjectLax",
+	"original_from":        "OriginalFrom",
+	"original_from_lax":    "OriginalFromLax",
+	"original_to":          "OriginalTo",
+	"original_to_lax":      "OriginalToLax",
+	"original_reply_to":    "OriginalReplyTo",
+	"original_cc":          "OriginalCC",
+	"original_cc_lax":      "OriginalCCLax",
+	"original_bcc":         "OriginalBCC",
+	"original_date":        "OriginalDate",
+	"original_date_lax":    "OriginalDateLax",
+	"original_message_id":  "OriginalMessageID",
+	"original_in_reply_to": "OriginalInReplyTo",
+	"original_references":  "OriginalReferences",
+	"signature":            "Signature",
+}
+
+func _LocaleFieldKey(name string) string {
+	for key, field := range _LocaleFields {
+		if field == name {
+			return key
+		}
+	}
+
+	return ""
+}
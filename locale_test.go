@@ -0,0 +1,125 @@
+package emailforwardparser
+
+import (
+	"io/fs"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+var _TestLocale = `{
+  "locale": "eo",
+  "subject": [
+    {"prefix": "Plus", "clients": ["Acme Mail"]}
+  ],
+  "separator": [
+    {"phrase": "Plusendita mesaĝo", "dashes": [5, 8], "clients": ["Acme Mail"]}
+  ],
+  "original_subject": [
+    {"label": "Temo", "clients": ["Acme Mail"]}
+  ],
+  "original_from": [
+    {"label": "Sendinto", "clients": ["Acme Mail"]}
+  ],
+  "original_to": [
+    {"label": "Ricevonto", "clients": ["Acme Mail"]}
+  ],
+  "original_date": [
+    {"label": "Sendita", "clients": ["Acme Mail"]}
+  ]
+}`
+
+func TestLoadLocales(t *testing.T) {
+	locales, err := LoadLocales(fstest.MapFS{"eo.json": {Data: []byte(_TestLocale)}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parser, err := NewParser(DefaultPatternSet().Extend(locales))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	email := strings.Join([]string{
+		_TestMessage,
+		"",
+		"------ Plusendita mesaĝo ------",
+		"Sendinto: John Doe <john.doe@acme.com>",
+		"Sendita: 2 jun 2022 10:15",
+		"Temo: " + _TestSubject,
+		"Ricevonto: bessie.berry@acme.com",
+		"",
+		_TestBody,
+	}, "\n")
+
+	result := parser.Read(email, "Plus: "+_TestSubject)
+
+	_TestEmail(t, result, "eo", false, true, true, false, false)
+
+	if result.Client != "Acme Mail" || result.Locale != "eo" {
+		t.Error("unexpected client", result.Client, result.Locale)
+	}
+
+	if len(result.Email.To) != 1 || result.Email.To[0].Address != _TestToAddress1 {
+		t.Error("unexpected recipients", result.Email.To)
+	}
+}
+
+func TestParseLocaleMerge(t *testing.T) {
+	set, err := ParseLocale([]byte(`{"locale": "de", "original_from": [{"label": "Von", "clients": ["Acme Mail"]}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	extended := DefaultPatternSet().Extend(set)
+
+	if len(extended.OriginalFrom) != len(DefaultPatternSet().OriginalFrom) {
+		t.Fatal("a known pattern should not be added twice")
+	}
+
+	for _, pattern := range extended.OriginalFrom {
+		if pattern.Expr == `(?m)^(\s*Von\s?:(.+))$` && !strings.HasSuffix(pattern.Source, ", Acme Mail (de)") {
+			t.Error("unexpected source", pattern.Source)
+		}
+	}
+}
+
+func TestParseLocaleErrors(t *testing.T) {
+	for _, data := range []string{
+		`{"locale": "eo", "original_sender": [{"label": "Sendinto", "clients": ["Acme Mail"]}]}`,
+		`{"locale": "eo", "original_from": [{"label": "Sendinto"}]}`,
+		`{"locale": "eo", "original_from": [{"phrase": "Sendinto", "clients": ["Acme Mail"]}]}`,
+		`{"locale": "eo", "original_from": [{"label": "Sendinto", "client": "Acme Mail"}]}`,
+		`{"locale": "eo", "separator": [{"phrase": "Plusendita", "dashes": [1, 2, 3], "clients": ["Acme Mail"]}]}`,
+		`{"locale": "eo", "separator": [{"phrase": "Plusendita", "information": true, "clients": ["Acme Mail"]}]}`,
+		`{"locale": "eo",`,
+	} {
+		if _, err := ParseLocale([]byte(data)); err == nil {
+			t.Error("expected an error", data)
+		}
+	}
+}
+
+func TestLocaleFiles(t *testing.T) {
+	names, err := fs.Glob(_LocaleFiles, "locales/*.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range names {
+		data, err := fs.ReadFile(_LocaleFiles, name)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := ParseLocale(data); err != nil {
+			t.Error(name, err)
+		}
+
+		locale := strings.TrimSuffix(strings.TrimPrefix(name, "locales/"), ".json")
+
+		if locale != "all" && !strings.Contains(string(data), `"locale": "`+locale+`"`) {
+			t.Error(name, "locale does not match the file name")
+		}
+	}
+}
@@ -19,8 +19,9 @@ func _LoopRegexesReplace(regexes []*regexp.Regexp, str string) string {
 	return match
 }
 
-func _LoopRegexesSplit(regexes []*regexp.Regexp, str string, highestPosition bool) []string {
+func _LoopRegexesSplit(regexes []*regexp.Regexp, str string, highestPosition bool) ([]string, *regexp.Regexp) {
 	var match []string
+	var regex *regexp.Regexp
 
 	for _, re := range regexes {
 		currentMatch := splitWithRegexp(re, str)
@@ -29,15 +30,20 @@ func _LoopRegexesSplit(regexes []*regexp.Regexp, str string, highestPosition boo
 			if highestPosition {
 				if match == nil || len(match[0]) > len(currentMatch[0]) {
 					match = currentMatch
+
+					regex = re
 				}
 			} else {
 				match = currentMatch
+
+				regex = re
+
 				break
 			}
 		}
 	}
 
-	return match
+	return match, regex
 }
 
 func _LoopRegexesMatch(regexes []*regexp.Regexp, str string, highestPosition bool) ([]string, *regexp.Regexp) {
@@ -72,3 +78,71 @@ func _LoopRegexesMatch(regexes []*regexp.Regexp, str string, highestPosition boo
 
 	return match, regex
 }
+
+// _MatchPatterns is _LoopRegexesMatch for the parser: it tries no pattern
+// once the context of ReadContext is done, and records the patterns tried for
+// ReadWithTrace; otherwise the patterns are tried in a single scan
+func (parser *Parser) _MatchPatterns(regexes []*regexp.Regexp, str string) ([]string, *regexp.Regexp) {
+	if parser._Done() {
+		return nil, nil
+	}
+
+	if parser.trace == nil {
+		return parser.patterns._Match(regexes, str)
+	}
+
+	match, pattern := _LoopRegexesMatch(regexes, str, true)
+	parser._Record(regexes, str, pattern)
+
+	return match, pattern
+}
+
+func (parser *Parser) _SplitPatterns(regexes []*regexp.Regexp, str string) ([]string, *regexp.Regexp) {
+	if parser._Done() {
+		return nil, nil
+	}
+
+	if parser.trace == nil {
+		return parser.patterns._Split(regexes, str)
+	}
+
+	match, pattern := _LoopRegexesSplit(regexes, str, true)
+	parser._Record(regexes, str, pattern)
+
+	return match, pattern
+}
+
+// _ReplacePatterns removes the matches of the first of regexes that matches
+// str
+func (parser *Parser) _ReplacePatterns(regexes []*regexp.Regexp, str string) string {
+	if parser._Done() {
+		return str
+	}
+
+	replaced := _LoopRegexesReplace(regexes, str)
+
+	if parser.trace == nil {
+		return replaced
+	}
+
+	var selected *regexp.Regexp
+
+	for _, re := range regexes {
+		if len(re.ReplaceAllString(str, "")) < len(str) {
+			selected = re
+			break
+		}
+	}
+
+	start := len(parser.trace.Events)
+
+	parser._Record(regexes, str, selected)
+
+	for i := start; i < len(parser.trace.Events); i++ {
+		if event := &parser.trace.Events[i]; event.Matched && !event.Selected {
+			event.Reason = "a previous pattern was removed"
+		}
+	}
+
+	return replaced
+}
@@ -0,0 +1,406 @@
+package emailforwardparser
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+	"unicode/utf8"
+)
+
+// CharsetReader, if set, is used to decode text parts and encoded-word
+// headers whose charset is not natively supported (utf-8, us-ascii,
+// iso-8859-1, iso-8859-15 and windows-1252).
+var CharsetReader func(charset string, input io.Reader) (io.Reader, error)
+
+// ErrUnsupportedCharset is returned by ReadMessage when a text part uses a
+// charset that cannot be decoded.
+var ErrUnsupportedCharset = errors.New("emailforwardparser: unsupported charset")
+
+type _MessagePart struct {
+	MediaType string
+	Text      string
+
+	Message *_AttachedMessage
+}
+
+type _AttachedMessage struct {
+	Header mail.Header
+	Parts  []_MessagePart
+}
+
+// ReadMessage parses a raw RFC 5322 message (such as an .eml file), selects
+// its best text part, decodes it and runs it through Read.
+//
+// If the message carries the original email as a message/rfc822 attachment
+// ("Forward as attachment"), the result is built from the attached message's
+// headers instead, and the outer text is returned in Message.
+func ReadMessage(r io.Reader) (ReadResult, error) {
+	return _DefaultParser.ReadMessage(r)
+}
+
+// ReadMessage is like the package-level ReadMessage, using the parser's
+// patterns.
+func (parser *Parser) ReadMessage(r io.Reader) (ReadResult, error) {
+	message, err := mail.ReadMessage(r)
+	if err != nil {
+		return ReadResult{}, err
+	}
+
+	subject := _DecodeHeader(message.Header.Get("Subject"))
+
+	parts, err := _ReadParts(message.Header, message.Body)
+	if err != nil {
+		return ReadResult{}, err
+	}
+
+	if attached := _SelectAttachedMessage(parts); attached != nil {
+		return parser._ReadAttachedMessage(_SelectPart(parts), attached), nil
+	}
+
+	part := _SelectPart(parts)
+
+	if part.MediaType == "text/html" {
+		return parser.ReadHTML(part.Text, subject), nil
+	}
+
+	return parser.Read(part.Text, subject), nil
+}
+
+func (parser *Parser) _ReadAttachedMessage(part _MessagePart, attached *_AttachedMessage) ReadResult {
+	header := attached.Header
+
+	from := parser._ParseHeaderMailboxes(header.Get("From"))
+	if len(from) == 0 {
+		from = append(from, parser._PrepareMailbox("", ""))
+	}
+
+	bodyPart := _SelectPart(attached.Parts)
+	bodyHTML := ""
+
+	if bodyPart.MediaType == "text/html" {
+		tokens := _TokenizeHTML(bodyPart.Text)
+		bodyHTML = _SanitizeHTML(bodyPart.Text, tokens, 0, len(bodyPart.Text))
+	}
+
+	date := trimString(header.Get("Date"))
+
+	dateTime, err := mail.ParseDate(date)
+	dateAmbiguity := DateAmbiguity(0)
+	if err != nil {
+		dateTime, dateAmbiguity, _ = ParseDate(date, "")
+	}
+
+	email := ReadResultEmail{
+		Body:      _PartText(bodyPart),
+		BodyHTML:  bodyHTML,
+		From:      from[0],
+		To:        parser._ParseHeaderMailboxes(header.Get("To")),
+		CC:        parser._ParseHeaderMailboxes(header.Get("Cc")),
+		BCC:       parser._ParseHeaderMailboxes(header.Get("Bcc")),
+		ReplyTo:   parser._ParseHeaderMailboxes(header.Get("Reply-To")),
+		Subject:   trimString(_DecodeHeader(header.Get("Subject"))),
+		Date:      date,
+		MessageID: _ParseMessageID(header.Get("Message-ID")),
+
+		InReplyTo:  _ParseMessageID(header.Get("In-Reply-To")),
+		References: _ParseMessageIDs(header.Get("References")),
+
+		DateTime:      dateTime,
+		DateAmbiguity: dateAmbiguity,
+	}
+
+	return ReadResult{
+		Forwarded: true,
+
+		Message: _PartText(part),
+
+		Email: email,
+
+		Kind: KindForward,
+
+		Matches: _AttachmentReadMatches(email),
+	}
+}
+
+func _PartText(part _MessagePart) string {
+	text := part.Text
+
+	if part.MediaType == "text/html" {
+		text = string(_HTMLToText(_TokenizeHTML(text)).Text)
+	}
+
+	text = _CarriageReturn.ReplaceAllString(text, "\n")
+
+	return trimString(preprocessString(text))
+}
+
+func (parser *Parser) _ParseHeaderMailboxes(value string) []Mailbox {
+	value = trimString(value)
+
+	if len(value) == 0 {
+		return []Mailbox{}
+	}
+
+	addressParser := mail.AddressParser{WordDecoder: &mime.WordDecoder{CharsetReader: _CharsetReader}}
+
+	addresses, err := addressParser.ParseList(value)
+	if err != nil {
+		return parser._ParseMailboxesLine(_DecodeHeader(value))
+	}
+
+	mailboxes := []Mailbox{}
+
+	for _, address := range addresses {
+		mailboxes = append(mailboxes, parser._PrepareMailbox(address.Name, address.Address))
+	}
+
+	return mailboxes
+}
+
+func _DecodeHeader(value string) string {
+	decoder := mime.WordDecoder{CharsetReader: _CharsetReader}
+
+	decoded, err := decoder.DecodeHeader(value)
+	if err != nil {
+		return value
+	}
+
+	return decoded
+}
+
+func _ReadParts(header mail.Header, body io.Reader) ([]_MessagePart, error) {
+	mediaType, params, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		mediaType = "text/plain"
+		params = map[string]string{}
+	}
+
+	// A multipart body without boundary cannot be split, and is read as text
+	if strings.HasPrefix(mediaType, "multipart/") && len(params["boundary"]) > 0 {
+		parts, err := _ReadMultipart(params["boundary"], body)
+
+		// The messages of delivery reports and digests are not forwarded
+		if mediaType == "multipart/report" || mediaType == "multipart/digest" {
+			parts = _WithoutAttachedMessages(parts)
+		}
+
+		return parts, err
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		mediaType = "text/plain"
+	}
+
+	if mediaType == "message/rfc822" {
+		return _ReadAttachedParts(header, body)
+	}
+
+	if !strings.HasPrefix(mediaType, "text/") {
+		return nil, nil
+	}
+
+	if disposition, _, _ := mime.ParseMediaType(header.Get("Content-Disposition")); disposition == "attachment" {
+		return nil, nil
+	}
+
+	text, err := _DecodePart(body, header.Get("Content-Transfer-Encoding"), params["charset"])
+	if err != nil {
+		return nil, err
+	}
+
+	return []_MessagePart{{MediaType: mediaType, Text: text}}, nil
+}
+
+// _ReadMultipart skips the parts that cannot be read, such as an attachment
+// in an unsupported charset, so they do not hide a readable body; it returns
+// the error of the first one if no part could be read
+func _ReadMultipart(boundary string, body io.Reader) ([]_MessagePart, error) {
+	reader := multipart.NewReader(body, boundary)
+	parts := []_MessagePart{}
+
+	var firstErr error
+
+	for {
+		part, err := reader.NextRawPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			// The rest of the body cannot be split into parts
+			firstErr = _FirstError(firstErr, err)
+			break
+		}
+
+		subparts, err := _ReadParts(mail.Header(part.Header), part)
+		if err != nil {
+			firstErr = _FirstError(firstErr, err)
+			continue
+		}
+
+		parts = append(parts, subparts...)
+	}
+
+	if len(parts) == 0 && firstErr != nil {
+		return nil, firstErr
+	}
+
+	return parts, nil
+}
+
+func _WithoutAttachedMessages(parts []_MessagePart) []_MessagePart {
+	kept := []_MessagePart{}
+
+	for _, part := range parts {
+		if part.Message == nil {
+			kept = append(kept, part)
+		}
+	}
+
+	return kept
+}
+
+func _FirstError(first error, err error) error {
+	if first != nil {
+		return first
+	}
+
+	return err
+}
+
+func _ReadAttachedParts(header mail.Header, body io.Reader) ([]_MessagePart, error) {
+	body = _DecodeTransferEncoding(body, header.Get("Content-Transfer-Encoding"))
+
+	message, err := mail.ReadMessage(body)
+	if err != nil {
+		return nil, err
+	}
+
+	parts, err := _ReadParts(message.Header, message.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return []_MessagePart{{
+		MediaType: "message/rfc822",
+		Message: &_AttachedMessage{
+			Header: message.Header,
+			Parts:  parts,
+		},
+	}}, nil
+}
+
+func _DecodeTransferEncoding(body io.Reader, transferEncoding string) io.Reader {
+	switch strings.ToLower(trimString(transferEncoding)) {
+	case "quoted-printable":
+		return quotedprintable.NewReader(body)
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, body)
+	}
+
+	return body
+}
+
+func _DecodePart(body io.Reader, transferEncoding string, charset string) (string, error) {
+	body = _DecodeTransferEncoding(body, transferEncoding)
+
+	if len(charset) > 0 {
+		reader, err := _CharsetReader(charset, body)
+		if err != nil {
+			return "", err
+		}
+
+		body = reader
+	}
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func _SelectPart(parts []_MessagePart) _MessagePart {
+	for _, part := range parts {
+		if part.MediaType == "text/plain" {
+			return part
+		}
+	}
+
+	for _, part := range parts {
+		if part.Message == nil {
+			return part
+		}
+	}
+
+	return _MessagePart{}
+}
+
+func _SelectAttachedMessage(parts []_MessagePart) *_AttachedMessage {
+	for _, part := range parts {
+		if part.Message != nil {
+			return part.Message
+		}
+	}
+
+	return nil
+}
+
+func _CharsetReader(charset string, input io.Reader) (io.Reader, error) {
+	switch strings.ToLower(trimString(charset)) {
+	case "", "utf-8", "utf8", "us-ascii", "ascii":
+		return input, nil
+	case "iso-8859-1", "latin1", "l1":
+		return _DecodeSingleByte(input, nil)
+	case "iso-8859-15", "latin-9":
+		return _DecodeSingleByte(input, _ISO885915)
+	case "windows-1252", "cp1252":
+		return _DecodeSingleByte(input, _Windows1252)
+	}
+
+	if CharsetReader != nil {
+		return CharsetReader(charset, input)
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrUnsupportedCharset, charset)
+}
+
+func _DecodeSingleByte(input io.Reader, table map[byte]rune) (io.Reader, error) {
+	data, err := io.ReadAll(input)
+	if err != nil {
+		return nil, err
+	}
+
+	buffer := bytes.Buffer{}
+	buffer.Grow(len(data))
+
+	for _, b := range data {
+		if r, ok := table[b]; ok {
+			buffer.WriteRune(r)
+		} else if b < utf8.RuneSelf {
+			buffer.WriteByte(b)
+		} else {
+			buffer.WriteRune(rune(b))
+		}
+	}
+
+	return &buffer, nil
+}
+
+var _ISO885915 = map[byte]rune{
+	0xA4: '€', 0xA6: 'Š', 0xA8: 'š', 0xB4: 'Ž', 0xB8: 'ž', 0xBC: 'Œ', 0xBD: 'œ', 0xBE: 'Ÿ',
+}
+
+var _Windows1252 = map[byte]rune{
+	0x80: '€', 0x82: '‚', 0x83: 'ƒ', 0x84: '„', 0x85: '…', 0x86: '†', 0x87: '‡', 0x88: 'ˆ',
+	0x89: '‰', 0x8A: 'Š', 0x8B: '‹', 0x8C: 'Œ', 0x8E: 'Ž', 0x91: '‘', 0x92: '’', 0x93: '“',
+	0x94: '”', 0x95: '•', 0x96: '–', 0x97: '—', 0x98: '˜', 0x99: '™', 0x9A: 'š', 0x9B: '›',
+	0x9C: 'œ', 0x9E: 'ž', 0x9F: 'Ÿ',
+}
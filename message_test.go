@@ -0,0 +1,309 @@
+package emailforwardparser
+
+import (
+	"encoding/base64"
+	"mime/quotedprintable"
+	"strings"
+	"testing"
+)
+
+func _QuotedPrintable(s string) string {
+	builder := strings.Builder{}
+
+	writer := quotedprintable.NewWriter(&builder)
+	writer.Write([]byte(s))
+	writer.Close()
+
+	return builder.String()
+}
+
+func TestReadMessage(t *testing.T) {
+	email, _ := _Read("gmail_fr_body", "")
+
+	message := strings.Join([]string{
+		"From: Bessie Berry <bessie.berry@acme.com>",
+		"To: Suzanne <suzanne@globex.corp>",
+		"Subject: =?UTF-8?Q?Fwd=3A_Integer_consequat_non_purus?=",
+		"MIME-Version: 1.0",
+		"Content-Type: multipart/alternative; boundary=\"boundary\"",
+		"",
+		"--boundary",
+		"Content-Type: text/html; charset=\"UTF-8\"",
+		"",
+		"<div>ignored</div>",
+		"--boundary",
+		"Content-Type: text/plain; charset=\"UTF-8\"",
+		"Content-Transfer-Encoding: quoted-printable",
+		"",
+		_QuotedPrintable(email),
+		"--boundary--",
+		"",
+	}, "\r\n")
+
+	result, err := ReadMessage(strings.NewReader(message))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_TestEmail(t, result, "gmail_fr_body", false, false, false, true, false)
+}
+
+func TestReadMessageBase64(t *testing.T) {
+	email, subject := _Read("outlook_live_body", "outlook_live_en_subject")
+
+	message := strings.Join([]string{
+		"From: Bessie Berry <bessie.berry@acme.com>",
+		"Subject: =?UTF-8?B?" + base64.StdEncoding.EncodeToString([]byte(subject)) + "?=",
+		"Content-Type: text/plain; charset=utf-8",
+		"Content-Transfer-Encoding: base64",
+		"",
+		base64.StdEncoding.EncodeToString([]byte(email)),
+		"",
+	}, "\r\n")
+
+	result, err := ReadMessage(strings.NewReader(message))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_TestEmail(t, result, "outlook_live_body", false, false, false, true, false)
+}
+
+func TestReadMessageCharset(t *testing.T) {
+	message := strings.Join([]string{
+		"Subject: =?ISO-8859-1?Q?Fwd:_R=E9sum=E9?=",
+		"Content-Type: text/plain; charset=windows-1252",
+		"",
+		"\x93Hello\x94 caf\xe9",
+		"",
+	}, "\r\n")
+
+	text, err := _DecodePart(strings.NewReader("\x93Hello\x94 caf\xe9"), "", "windows-1252")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if text != "“Hello” café" {
+		t.Error("unexpected windows-1252 decoding", text)
+	}
+
+	result, err := ReadMessage(strings.NewReader(message))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Email.Subject != "Résumé" {
+		t.Error("unexpected subject", result.Email.Subject)
+	}
+
+	_, err = ReadMessage(strings.NewReader("Content-Type: text/plain; charset=koi8-r\r\n\r\nbody\r\n"))
+	if err == nil {
+		t.Error("expected an unsupported charset error")
+	}
+}
+
+func TestReadMessageAttachment(t *testing.T) {
+	message := strings.Join([]string{
+		"From: Bessie Berry <bessie.berry@acme.com>",
+		"Subject: Fw: Integer consequat non purus",
+		"Content-Type: multipart/mixed; boundary=\"outer\"",
+		"",
+		"--outer",
+		"Content-Type: text/plain; charset=utf-8",
+		"",
+		_TestMessage,
+		"--outer",
+		"Content-Type: message/rfc822",
+		"Content-Disposition: attachment; filename=\"original.eml\"",
+		"",
+		"From: \"John Doe\" <john.doe@acme.com>",
+		"To: bessie.berry@acme.com, Suzanne <suzanne@globex.corp>",
+		"Cc: Walter Sheltan <walter.sheltan@acme.com>, Nicholas <nicholas@globex.corp>",
+		"Date: Mon, 25 Oct 2021 11:17:21 +0300",
+		"Subject: =?UTF-8?Q?Integer_consequat_non_purus?=",
+		"Message-ID: <original@acme.com>",
+		"References: <first@globex.corp>",
+		"\t<second@globex.corp>",
+		"Reply-To: John Doe <john.doe@acme.com>",
+		"Content-Type: text/plain; charset=utf-8",
+		"Content-Transfer-Encoding: quoted-printable",
+		"",
+		_QuotedPrintable(_TestBody),
+		"--outer--",
+		"",
+	}, "\r\n")
+
+	result, err := ReadMessage(strings.NewReader(message))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_TestEmail(t, result, "attachment", false, false, false, false, false)
+
+	if result.Email.To[1].Name != _TestToName2 || result.Email.To[1].Address != _TestToAddress2 {
+		t.Error("unexpected second recipient", result.Email.To[1])
+	}
+
+	if result.Email.Date != "Mon, 25 Oct 2021 11:17:21 +0300" {
+		t.Error("unexpected date", result.Email.Date)
+	}
+
+	if result.Email.MessageID != "<original@acme.com>" {
+		t.Error("unexpected message id", result.Email.MessageID)
+	}
+
+	if len(result.Email.References) != 2 || result.Email.References[1] != "<second@globex.corp>" {
+		t.Error("unexpected references", result.Email.References)
+	}
+
+	if len(result.Email.ReplyTo) != 1 || result.Email.ReplyTo[0].Address != _TestFromAddress {
+		t.Error("unexpected reply-to", result.Email.ReplyTo)
+	}
+
+	if result.Matches.Confidence() != ConfidenceHigh || result.Matches.From.Strategy != StrategyAttachment || result.Matches.BCC.Confidence != ConfidenceNone {
+		t.Error("unexpected matches", result.Matches)
+	}
+}
+
+func TestReadMessageHTML(t *testing.T) {
+	message := strings.Join([]string{
+		"Subject: Fwd: Integer consequat non purus",
+		"Content-Type: text/html; charset=utf-8",
+		"Content-Transfer-Encoding: quoted-printable",
+		"",
+		_QuotedPrintable(_ReadHTMLFixture("gmail_en_body")),
+		"",
+	}, "\r\n")
+
+	result, err := ReadMessage(strings.NewReader(message))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_TestEmail(t, result, "gmail_en_body", false, false, false, false, false)
+
+	if len(result.Email.BodyHTML) == 0 {
+		t.Error("len(result.Email.BodyHTML) == 0")
+	}
+}
+
+func TestReadMessageUnreadableParts(t *testing.T) {
+	email, _ := _Read("gmail_en_body", "")
+
+	for name, parts := range map[string][]string{
+		"unsupported charset": {
+			"Content-Type: text/plain; charset=koi8-r",
+			"",
+			"body",
+		},
+		"malformed nested part": {
+			"Content-Type: message/rfc822",
+			"Content-Transfer-Encoding: base64",
+			"",
+			"not base64",
+		},
+		"truncated nested multipart": {
+			"Content-Type: multipart/alternative; boundary=\"inner\"",
+			"",
+			"--inner",
+			"Content-Type: text/html; charset=x-unknown",
+			"",
+			"<div>body</div>",
+		},
+	} {
+		message := strings.Join(append(append([]string{
+			"Subject: Fwd: Integer consequat non purus",
+			"Content-Type: multipart/mixed; boundary=\"outer\"",
+			"",
+			"--outer",
+		}, parts...),
+			"--outer",
+			"Content-Type: text/plain; charset=utf-8",
+			"",
+			email,
+			"--outer--",
+			"",
+		), "\r\n")
+
+		result, err := ReadMessage(strings.NewReader(message))
+		if err != nil {
+			t.Fatal(name, err)
+		}
+
+		_TestEmail(t, result, "gmail_en_body", false, false, false, true, false)
+	}
+}
+
+func TestReadMessageWithoutBoundary(t *testing.T) {
+	email, _ := _Read("gmail_en_body", "")
+
+	result, err := ReadMessage(strings.NewReader("Subject: Fwd: Integer consequat non purus\r\nContent-Type: multipart/mixed\r\n\r\n" + email))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_TestEmail(t, result, "gmail_en_body", false, false, false, true, false)
+}
+
+func TestReadMessageReportAndDigest(t *testing.T) {
+	attached := []string{
+		"Content-Type: message/rfc822",
+		"",
+		"From: \"John Doe\" <john.doe@acme.com>",
+		"Subject: Integer consequat non purus",
+		"",
+		_TestBody,
+	}
+
+	for _, mediaType := range []string{"multipart/report; report-type=delivery-status", "multipart/digest"} {
+		message := strings.Join(append(append([]string{
+			"From: Mail Delivery Subsystem <mailer-daemon@acme.com>",
+			"Subject: Delivery Status Notification (Failure)",
+			"Content-Type: " + mediaType + "; boundary=\"outer\"",
+			"",
+			"--outer",
+			"Content-Type: text/plain; charset=utf-8",
+			"",
+			"Your message could not be delivered.",
+			"--outer",
+		}, attached...),
+			"--outer--",
+			"",
+		), "\r\n")
+
+		result, err := ReadMessage(strings.NewReader(message))
+		if err != nil {
+			t.Fatal(mediaType, err)
+		}
+
+		if result.Forwarded || result.Email.From.Address == _TestFromAddress {
+			t.Error(mediaType, "read as a forward", result)
+		}
+	}
+}
+
+func TestReadMessageAttachmentMessageID(t *testing.T) {
+	message := strings.Join([]string{
+		"Subject: Fw: Integer consequat non purus",
+		"Content-Type: multipart/mixed; boundary=\"outer\"",
+		"",
+		"--outer",
+		"Content-Type: message/rfc822",
+		"",
+		"From: \"John Doe\" <john.doe@acme.com>",
+		"Message-ID:  <original@acme.com> (sent by webmail)",
+		"",
+		_TestBody,
+		"--outer--",
+		"",
+	}, "\r\n")
+
+	result, err := ReadMessage(strings.NewReader(message))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Email.MessageID != "<original@acme.com>" {
+		t.Errorf("unexpected message id %q", result.Email.MessageID)
+	}
+}
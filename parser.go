@@ -0,0 +1,351 @@
+package emailforwardparser
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+
+	regexp "github.com/wasilibs/go-re2"
+)
+
+// Pattern is a regular expression (RE2 syntax) recognizing part of a
+// forward. Source names the clients and locales it was written for, such as
+// "Apple Mail (de), Gmail (de)" or "Outlook Live / 365 (all locales)"; it is
+// used to detect ReadResult.Client and ReadResult.Locale and may be empty.
+type Pattern struct {
+	Expr   string `json:"expr"`
+	Source string `json:"source"`
+}
+
+// PatternSet holds the patterns of a Parser, per field. The patterns of a
+// field are tried in turn and the match closest to the top of the text wins
+// (the first pattern on a tie).
+//
+// The capture groups of each field follow the conventions of the default
+// set, returned by DefaultPatternSet.
+type PatternSet struct {
+	// Subject prefixes, capturing the original subject: `(?m)^Fwd:(.*)`
+	Subject []Pattern
+	// Reply prefixes, capturing the original subject: `(?m)^Re:(.*)`
+	ReplySubject []Pattern
+	// Lines that separate the message from the forwarded email:
+	// `(?m)^\s*-{8,10}\s*Forwarded message\s*-{8,10}\s*`
+	Separator []Pattern
+	// Separators that also hold the date and sender of the original email,
+	// in the "date", "from_name" and "from_address" named groups
+	SeparatorWithInformation []Pattern
+
+	// Header lines of the original email, capturing their value. The "Lax"
+	// variants are tried when the header block is not split into lines.
+	OriginalSubject    []Pattern
+	OriginalSubjectLax []Pattern
+	// Capturing the whole line, then its value: `(?m)^(\s*Von\s?:(.+))$`
+	OriginalFrom []Pattern
+	// Capturing the whole header, the name, then the address
+	OriginalFromLax []Pattern
+	OriginalTo      []Pattern
+	OriginalToLax   []Pattern
+	OriginalReplyTo []Pattern
+	OriginalCC      []Pattern
+	OriginalCCLax   []Pattern
+	OriginalBCC     []Pattern
+	OriginalDate    []Pattern
+	OriginalDateLax []Pattern
+	// Message identifiers, usually only written by Thunderbird and Outlook
+	OriginalMessageID  []Pattern
+	OriginalInReplyTo  []Pattern
+	OriginalReferences []Pattern
+
+	// Lines that start the signature of the forwarder or a disclaimer in the
+	// message, for WithSignatures: `(?m)^--[ \t]?$`
+	Signature []Pattern
+
+	// Mailbox formats, capturing the name then the address, or the address
+	// only
+	Mailbox []Pattern
+	// Valid addresses
+	MailboxAddress []Pattern
+}
+
+// DefaultPatternSet returns a copy of the patterns used by Read, covering
+// the clients and locales listed in the README.
+func DefaultPatternSet() PatternSet {
+	return _DefaultPatternSet.Extend(PatternSet{})
+}
+
+var _DefaultPatternSet = _MustLoadDefaultPatternSet()
+
+func _MustLoadDefaultPatternSet() PatternSet {
+	locales, err := fs.Sub(_LocaleFiles, "locales")
+	if err != nil {
+		panic(err)
+	}
+
+	set, err := LoadLocales(locales)
+	if err != nil {
+		panic(err)
+	}
+
+	return set.Extend(PatternSet{
+		Mailbox:        _Mailbox,
+		MailboxAddress: _MailboxAddress,
+	})
+}
+
+// Extend returns a copy of the set with the patterns of other appended to
+// each field. A pattern already in the set only adds its Source to it.
+func (set PatternSet) Extend(other PatternSet) PatternSet {
+	extended := PatternSet{}
+
+	setFields, otherFields, extendedFields := set._Fields(), other._Fields(), extended._Fields()
+
+	for i := range setFields {
+		patterns := append([]Pattern{}, *setFields[i].Patterns...)
+
+		for _, pattern := range *otherFields[i].Patterns {
+			patterns = _MergePattern(patterns, pattern)
+		}
+
+		*extendedFields[i].Patterns = patterns
+	}
+
+	return extended
+}
+
+// _MergePattern appends pattern to patterns, or merges its sources into
+// those of the pattern with the same expression
+func _MergePattern(patterns []Pattern, pattern Pattern) []Pattern {
+	for i := range patterns {
+		if patterns[i].Expr == pattern.Expr {
+			patterns[i].Source = _FormatClientSources(_MergeClientSources(_ParseClientSources(patterns[i].Source), _ParseClientSources(pattern.Source)))
+
+			return patterns
+		}
+	}
+
+	return append(patterns, pattern)
+}
+
+type _PatternField struct {
+	Name     string
+	Patterns *[]Pattern
+}
+
+func (set *PatternSet) _Fields() []_PatternField {
+	return []_PatternField{
+		{"Subject", &set.Subject},
+		{"ReplySubject", &set.ReplySubject},
+		{"Separator", &set.Separator},
+		{"SeparatorWithInformation", &set.SeparatorWithInformation},
+		{"OriginalSubject", &set.OriginalSubject},
+		{"OriginalSubjectLax", &set.OriginalSubjectLax},
+		{"OriginalFrom", &set.OriginalFrom},
+		{"OriginalFromLax", &set.OriginalFromLax},
+		{"OriginalTo", &set.OriginalTo},
+		{"OriginalToLax", &set.OriginalToLax},
+		{"OriginalReplyTo", &set.OriginalReplyTo},
+		{"OriginalCC", &set.OriginalCC},
+		{"OriginalCCLax", &set.OriginalCCLax},
+		{"OriginalBCC", &set.OriginalBCC},
+		{"OriginalDate", &set.OriginalDate},
+		{"OriginalDateLax", &set.OriginalDateLax},
+		{"OriginalMessageID", &set.OriginalMessageID},
+		{"OriginalInReplyTo", &set.OriginalInReplyTo},
+		{"OriginalReferences", &set.OriginalReferences},
+		{"Signature", &set.Signature},
+		{"Mailbox", &set.Mailbox},
+		{"MailboxAddress", &set.MailboxAddress},
+	}
+}
+
+type _Patterns struct {
+	Subject                  []*regexp.Regexp
+	ReplySubject             []*regexp.Regexp
+	Separator                []*regexp.Regexp
+	SeparatorWithInformation []*regexp.Regexp
+
+	OriginalSubject    []*regexp.Regexp
+	OriginalSubjectLax []*regexp.Regexp
+	OriginalFrom       []*regexp.Regexp
+	OriginalFromLax    []*regexp.Regexp
+	OriginalTo         []*regexp.Regexp
+	OriginalToLax      []*regexp.Regexp
+	OriginalReplyTo    []*regexp.Regexp
+	OriginalCC         []*regexp.Regexp
+	OriginalCCLax      []*regexp.Regexp
+	OriginalBCC        []*regexp.Regexp
+	OriginalDate       []*regexp.Regexp
+	OriginalDateLax    []*regexp.Regexp
+	OriginalMessageID  []*regexp.Regexp
+	OriginalInReplyTo  []*regexp.Regexp
+	OriginalReferences []*regexp.Regexp
+
+	Signature []*regexp.Regexp
+
+	Mailbox        []*regexp.Regexp
+	MailboxAddress []*regexp.Regexp
+
+	// OriginalSubject then OriginalSubjectLax
+	OriginalSubjectAndLax []*regexp.Regexp
+
+	// ReplySubject ignoring case, for Kind
+	ReplyPrefixes []*regexp.Regexp
+	// Subject then ReplySubject, ignoring case, for NormalizeSubject
+	SubjectPrefixes []*regexp.Regexp
+
+	// The separators that also hold the date and sender of the original
+	// email, which introduce replies as well as forwards
+	Replies map[*regexp.Regexp]bool
+
+	// The alternation of each list of patterns, see _CompileAlternations
+	Alternations map[_AlternationKey]*_Alternation
+
+	// The clients and locales each pattern was written for, and the name of
+	// its field
+	Sources map[*regexp.Regexp][]_ClientSource
+	Names   map[*regexp.Regexp]string
+	// Each pattern with its sources formatted, for ReadMatches and traces
+	Definitions map[*regexp.Regexp]Pattern
+}
+
+func (patterns *_Patterns) _Fields() []*[]*regexp.Regexp {
+	return []*[]*regexp.Regexp{
+		&patterns.Subject,
+		&patterns.ReplySubject,
+		&patterns.Separator,
+		&patterns.SeparatorWithInformation,
+		&patterns.OriginalSubject,
+		&patterns.OriginalSubjectLax,
+		&patterns.OriginalFrom,
+		&patterns.OriginalFromLax,
+		&patterns.OriginalTo,
+		&patterns.OriginalToLax,
+		&patterns.OriginalReplyTo,
+		&patterns.OriginalCC,
+		&patterns.OriginalCCLax,
+		&patterns.OriginalBCC,
+		&patterns.OriginalDate,
+		&patterns.OriginalDateLax,
+		&patterns.OriginalMessageID,
+		&patterns.OriginalInReplyTo,
+		&patterns.OriginalReferences,
+		&patterns.Signature,
+		&patterns.Mailbox,
+		&patterns.MailboxAddress,
+	}
+}
+
+func _CompilePatterns(set PatternSet) (*_Patterns, error) {
+	patterns := &_Patterns{
+		Sources:     map[*regexp.Regexp][]_ClientSource{},
+		Names:       map[*regexp.Regexp]string{},
+		Definitions: map[*regexp.Regexp]Pattern{},
+	}
+
+	compiledFields := patterns._Fields()
+
+	for i, field := range set._Fields() {
+		compiled := make([]*regexp.Regexp, 0, len(*field.Patterns))
+
+		for _, pattern := range *field.Patterns {
+			re, err := regexp.Compile(pattern.Expr)
+			if err != nil {
+				return nil, fmt.Errorf("emailforwardparser: invalid %s pattern %q: %w", field.Name, pattern.Expr, err)
+			}
+
+			compiled = append(compiled, re)
+			patterns.Sources[re] = _ParseClientSources(pattern.Source)
+			patterns.Names[re] = field.Name
+			patterns.Definitions[re] = Pattern{Expr: re.String(), Source: _FormatClientSources(patterns.Sources[re])}
+		}
+
+		*compiledFields[i] = compiled
+	}
+
+	patterns.OriginalSubjectAndLax = append(append([]*regexp.Regexp{}, patterns.OriginalSubject...), patterns.OriginalSubjectLax...)
+
+	for _, re := range patterns.Subject {
+		patterns.SubjectPrefixes = append(patterns.SubjectPrefixes, patterns._FoldCase(re))
+	}
+
+	for _, re := range patterns.ReplySubject {
+		patterns.ReplyPrefixes = append(patterns.ReplyPrefixes, patterns._FoldCase(re))
+	}
+
+	patterns.SubjectPrefixes = append(patterns.SubjectPrefixes, patterns.ReplyPrefixes...)
+
+	patterns.Replies = map[*regexp.Regexp]bool{}
+
+	for _, information := range patterns.SeparatorWithInformation {
+		expr := _LocaleNamedGroup.ReplaceAllString(information.String(), "(?:")
+
+		for _, separator := range patterns.Separator {
+			if separator.String() == expr {
+				patterns.Replies[separator] = true
+			}
+		}
+	}
+
+	patterns._CompileAlternations()
+
+	return patterns, nil
+}
+
+// A prefix of a single letter, such as the Italian "I:" and "R:", only told
+// from a word by its case
+var _LetterPrefix = regexp.MustCompile(`^\(\?m\)\^\pL:`)
+
+// _FoldCase returns re ignoring case, with the sources and name of re,
+// unless it is the prefix of a single letter
+func (patterns *_Patterns) _FoldCase(re *regexp.Regexp) *regexp.Regexp {
+	if _LetterPrefix.MatchString(re.String()) {
+		return re
+	}
+
+	folded, err := regexp.Compile(`(?i)` + re.String())
+	if err != nil {
+		return re
+	}
+
+	patterns.Sources[folded] = patterns.Sources[re]
+	patterns.Names[folded] = patterns.Names[re]
+	patterns.Definitions[folded] = patterns.Definitions[re]
+
+	return folded
+}
+
+// Parser parses forwarded emails with a given set of patterns. It is safe
+// for concurrent use.
+type Parser struct {
+	patterns   *_Patterns
+	limits     Limits
+	signatures bool
+
+	// The trace and context of a single read, for ReadWithTrace and
+	// ReadContext
+	trace *Trace
+	ctx   context.Context
+}
+
+// NewParser returns a parser using the given patterns, usually
+// DefaultPatternSet extended with patterns for other clients. It returns an
+// error if a pattern does not compile.
+func NewParser(set PatternSet) (*Parser, error) {
+	patterns, err := _CompilePatterns(set)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Parser{patterns: patterns, limits: DefaultLimits}, nil
+}
+
+var _DefaultParser = _MustNewParser(DefaultPatternSet())
+
+func _MustNewParser(set PatternSet) *Parser {
+	parser, err := NewParser(set)
+	if err != nil {
+		panic(err)
+	}
+
+	return parser
+}
@@ -0,0 +1,72 @@
+package emailforwardparser
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParserExtend(t *testing.T) {
+	email := strings.Join([]string{
+		_TestMessage,
+		"",
+		"===== Weitergeleitet mit Acme Mail =====",
+		"Von: John Doe <john.doe@acme.com>",
+		"Datum: 2. Juni 2022 um 10:15",
+		"Betreff: " + _TestSubject,
+		"An: bessie.berry@acme.com",
+		"",
+		_TestBody,
+	}, "\n")
+
+	if Read(email, "").Forwarded {
+		t.Fatal("the default patterns should not know the separator")
+	}
+
+	// Acme Mail writes the headers as other clients do, which must vote for
+	// it too for it to be detected
+	parser, err := NewParser(DefaultPatternSet().Extend(PatternSet{
+		Separator:       []Pattern{{`(?m)^\s*=+ Weitergeleitet mit Acme Mail =+\s*`, "Acme Mail (de)"}},
+		OriginalFrom:    []Pattern{{`(?m)^(\s*Von\s?:(.+))$`, "Acme Mail (de)"}},
+		OriginalSubject: []Pattern{{`(?im)^Betreff\s?:(.+)`, "Acme Mail (de)"}},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := parser.Read(email, "")
+
+	_TestEmail(t, result, "acme_mail", false, true, true, false, false)
+
+	if result.Client != "Acme Mail" || result.Locale != "de" {
+		t.Error("unexpected client", result.Client, result.Locale)
+	}
+}
+
+func TestParserOverride(t *testing.T) {
+	set := DefaultPatternSet()
+	set.Subject = []Pattern{{`(?m)^Fwd:(.*)`, "Gmail (all locales)"}}
+
+	parser, err := NewParser(set)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	email, subject := _Read("outlook_live_body", "outlook_live_en_subject")
+
+	if parser.Read(email, subject).Forwarded {
+		t.Error("the overridden subject patterns should not match", subject)
+	}
+
+	if !Read(email, subject).Forwarded {
+		t.Error("the default patterns should not be changed by the override", subject)
+	}
+}
+
+func TestNewParserInvalidPattern(t *testing.T) {
+	set := DefaultPatternSet()
+	set.OriginalDate = append(set.OriginalDate, Pattern{Expr: `(?m)^Date\s?:(.+$`})
+
+	if _, err := NewParser(set); err == nil || !strings.Contains(err.Error(), "OriginalDate") {
+		t.Error("expected an invalid OriginalDate pattern error", err)
+	}
+}
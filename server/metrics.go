@@ -0,0 +1,119 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	efp "github.com/darnfish/email-forward-parser"
+)
+
+// _Metrics counts requests and reads; efp_forwards_total breaks down the
+// forwards of efp_reads_total{forwarded="true"} by client, so the share of
+// client="unknown" gives the rate of forwards whose client is not detected.
+type _Metrics struct {
+	mutex sync.Mutex
+
+	requests map[string]float64 // by path and status
+	reads    map[string]float64 // by input type and result
+	forwards map[string]float64 // by client and locale
+	matches  map[string]float64 // by confidence
+
+	readSeconds float64
+	readCount   float64
+}
+
+func _NewMetrics() *_Metrics {
+	return &_Metrics{
+		requests: map[string]float64{},
+		reads:    map[string]float64{},
+		forwards: map[string]float64{},
+		matches:  map[string]float64{},
+	}
+}
+
+func (metrics *_Metrics) _ObserveRequest(path string, status int) {
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+
+	if path != "/read" && path != "/healthz" && path != "/metrics" {
+		path = "other"
+	}
+
+	metrics.requests[_Labels("path", path, "status", strconv.Itoa(status))]++
+}
+
+func (metrics *_Metrics) _ObserveRead(inputType string, result efp.ReadResult, duration time.Duration) {
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+
+	metrics.reads[_Labels("type", inputType, "forwarded", strconv.FormatBool(result.Forwarded))]++
+	metrics.readSeconds += duration.Seconds()
+	metrics.readCount++
+
+	if result.Forwarded {
+		client := result.Client
+
+		if len(client) == 0 {
+			client = "unknown"
+		}
+
+		metrics.forwards[_Labels("client", client, "locale", result.Locale)]++
+		metrics.matches[_Labels("confidence", result.Matches.Confidence().String())]++
+	}
+}
+
+func (metrics *_Metrics) _WriteTo(w io.Writer) {
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+
+	for _, metric := range []struct {
+		Name   string
+		Help   string
+		Values map[string]float64
+	}{
+		{"efp_http_requests_total", "HTTP requests, by path and status.", metrics.requests},
+		{"efp_reads_total", "Bodies and messages read, by input type and whether they were forwards.", metrics.reads},
+		{"efp_forwards_total", "Forwards read, by detected client and locale.", metrics.forwards},
+		{"efp_forwards_by_confidence_total", "Forwards read, by confidence.", metrics.matches},
+	} {
+		fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s counter\n", metric.Name, metric.Help, metric.Name)
+
+		labels := make([]string, 0, len(metric.Values))
+
+		for label := range metric.Values {
+			labels = append(labels, label)
+		}
+
+		sort.Strings(labels)
+
+		for _, label := range labels {
+			fmt.Fprintf(w, "%s%s %s\n", metric.Name, label, _FormatValue(metric.Values[label]))
+		}
+	}
+
+	fmt.Fprintf(w, "# HELP efp_read_duration_seconds Time spent reading.\n# TYPE efp_read_duration_seconds summary\n")
+	fmt.Fprintf(w, "efp_read_duration_seconds_sum %s\n", _FormatValue(metrics.readSeconds))
+	fmt.Fprintf(w, "efp_read_duration_seconds_count %s\n", _FormatValue(metrics.readCount))
+}
+
+func _FormatValue(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+var _LabelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// _Labels formats label pairs, such as {client="Gmail",locale="en"}
+func _Labels(pairs ...string) string {
+	labels := make([]string, 0, len(pairs)/2)
+
+	for i := 0; i < len(pairs); i += 2 {
+		labels = append(labels, pairs[i]+`="`+_LabelValueEscaper.Replace(pairs[i+1])+`"`)
+	}
+
+	return "{" + strings.Join(labels, ",") + "}"
+}
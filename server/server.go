@@ -0,0 +1,209 @@
+// Package server exposes the parser over HTTP, for services not written in
+// Go.
+//
+// POST /read takes a plain text body (text/plain), an HTML body (text/html)
+// or a raw message (message/rfc822) and returns the ReadResult as JSON; the
+// subject of plain text and HTML bodies is given in the "subject" query
+// parameter. GET /healthz reports that the server is up, and GET /metrics
+// returns counters in the Prometheus text format.
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"time"
+
+	efp "github.com/darnfish/email-forward-parser"
+)
+
+const (
+	_DefaultMaxBodySize = 10 << 20
+	_DefaultTimeout     = 10 * time.Second
+)
+
+// Options configures a Handler.
+type Options struct {
+	// Parser reads the requests; the default patterns are used if nil.
+	Parser *efp.Parser
+	// MaxBodySize is the size of the largest request body accepted, in
+	// bytes (10 MiB if zero). Plain text bodies are also bound by the limits
+	// of the parser.
+	MaxBodySize int64
+	// Timeout bounds the time spent on a request (10 seconds if zero).
+	Timeout time.Duration
+}
+
+// Handler serves the HTTP API. It is safe for concurrent use.
+type Handler struct {
+	parser      *efp.Parser
+	maxBodySize int64
+
+	metrics *_Metrics
+	mux     *http.ServeMux
+}
+
+// NewHandler returns a handler serving the HTTP API with the given options.
+func NewHandler(options Options) *Handler {
+	handler := &Handler{
+		parser:      options.Parser,
+		maxBodySize: options.MaxBodySize,
+
+		metrics: _NewMetrics(),
+		mux:     http.NewServeMux(),
+	}
+
+	if handler.maxBodySize <= 0 {
+		handler.maxBodySize = _DefaultMaxBodySize
+	}
+
+	timeout := options.Timeout
+
+	if timeout <= 0 {
+		timeout = _DefaultTimeout
+	}
+
+	handler.mux.Handle("/read", http.TimeoutHandler(http.HandlerFunc(handler._ServeRead), timeout, `{"error":"timeout"}`))
+	handler.mux.HandleFunc("/healthz", handler._ServeHealth)
+	handler.mux.HandleFunc("/metrics", handler._ServeMetrics)
+
+	return handler
+}
+
+// ServeHTTP implements http.Handler.
+func (handler *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	recorder := &_StatusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+	handler.mux.ServeHTTP(recorder, r)
+
+	handler.metrics._ObserveRequest(r.URL.Path, recorder.status)
+}
+
+func (handler *Handler) _ServeRead(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		_WriteError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	mediaType := "text/plain"
+
+	if contentType := r.Header.Get("Content-Type"); len(contentType) > 0 {
+		parsed, _, err := mime.ParseMediaType(contentType)
+		if err != nil {
+			_WriteError(w, http.StatusUnsupportedMediaType, "invalid content type")
+			return
+		}
+
+		mediaType = parsed
+	}
+
+	if _, ok := _MediaTypes[mediaType]; !ok {
+		_WriteError(w, http.StatusUnsupportedMediaType, "unsupported content type "+mediaType)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, handler.maxBodySize))
+	if err != nil {
+		var maxBytesError *http.MaxBytesError
+
+		if errors.As(err, &maxBytesError) {
+			_WriteError(w, http.StatusRequestEntityTooLarge, "request body too large")
+			return
+		}
+
+		_WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	start := time.Now()
+
+	result, err := handler._Read(r.Context(), mediaType, body, r.URL.Query().Get("subject"))
+	if err != nil {
+		var tooLarge *efp.InputTooLargeError
+
+		switch {
+		case errors.As(err, &tooLarge):
+			_WriteError(w, http.StatusRequestEntityTooLarge, err.Error())
+		case r.Context().Err() != nil:
+			// The timeout handler already responded
+		default:
+			_WriteError(w, http.StatusBadRequest, err.Error())
+		}
+
+		return
+	}
+
+	handler.metrics._ObserveRead(_MediaTypes[mediaType], result, time.Since(start))
+
+	w.Header().Set("Content-Type", "application/json")
+
+	json.NewEncoder(w).Encode(result)
+}
+
+// The media types accepted by /read, and their name in metrics
+var _MediaTypes = map[string]string{
+	"text/plain":     "text",
+	"text/html":      "html",
+	"message/rfc822": "message",
+}
+
+func (handler *Handler) _Read(ctx context.Context, mediaType string, body []byte, subject string) (efp.ReadResult, error) {
+	parser := handler.parser
+
+	switch mediaType {
+	case "message/rfc822":
+		if parser == nil {
+			return efp.ReadMessageContext(ctx, bytes.NewReader(body))
+		}
+
+		return parser.ReadMessageContext(ctx, bytes.NewReader(body))
+	case "text/html":
+		if parser == nil {
+			return efp.ReadHTMLContext(ctx, string(body), subject)
+		}
+
+		return parser.ReadHTMLContext(ctx, string(body), subject)
+	}
+
+	if parser == nil {
+		return efp.ReadContext(ctx, string(body), subject)
+	}
+
+	return parser.ReadContext(ctx, string(body), subject)
+}
+
+func (handler *Handler) _ServeHealth(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	io.WriteString(w, `{"status":"ok"}`+"\n")
+}
+
+func (handler *Handler) _ServeMetrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+
+	handler.metrics._WriteTo(w)
+}
+
+func _WriteError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
+
+type _StatusRecorder struct {
+	http.ResponseWriter
+
+	status int
+}
+
+func (recorder *_StatusRecorder) WriteHeader(status int) {
+	recorder.status = status
+
+	recorder.ResponseWriter.WriteHeader(status)
+}
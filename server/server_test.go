@@ -0,0 +1,175 @@
+package server
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+
+	efp "github.com/darnfish/email-forward-parser"
+)
+
+func _Fixture(t *testing.T, name string) string {
+	data, err := os.ReadFile("../fixtures/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(data)
+}
+
+func _Post(t *testing.T, handler http.Handler, target string, contentType string, body string) (*httptest.ResponseRecorder, efp.ReadResult) {
+	request := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+	request.Header.Set("Content-Type", contentType)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+
+	result := efp.ReadResult{}
+
+	if recorder.Code == http.StatusOK {
+		if err := json.Unmarshal(recorder.Body.Bytes(), &result); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return recorder, result
+}
+
+func TestRead(t *testing.T) {
+	handler := NewHandler(Options{})
+
+	recorder, result := _Post(t, handler, "/read", "text/plain; charset=utf-8", _Fixture(t, "gmail_cs_body.txt"))
+
+	if recorder.Code != http.StatusOK || recorder.Header().Get("Content-Type") != "application/json" {
+		t.Fatal("unexpected response", recorder.Code, recorder.Body.String())
+	}
+
+	if !result.Forwarded || result.Client != "Gmail" || result.Email.From.Address != "john.doe@acme.com" {
+		t.Error("unexpected result", result)
+	}
+
+	// The subject is needed to read this forward
+	subject := url.QueryEscape("Fwd: Integer consequat non purus")
+
+	_, result = _Post(t, handler, "/read?subject="+subject, "text/html", _Fixture(t, "new_outlook_2019_en_body.html"))
+
+	if !result.Forwarded || result.Email.Subject != "Integer consequat non purus" {
+		t.Error("unexpected HTML result", result)
+	}
+
+	message := "From: bessie.berry@acme.com\r\nSubject: Fwd: Integer consequat non purus\r\n\r\n" + _Fixture(t, "gmail_en_body.txt")
+
+	_, result = _Post(t, handler, "/read", "message/rfc822", message)
+
+	if !result.Forwarded || result.Email.Subject != "Integer consequat non purus" {
+		t.Error("unexpected message result", result)
+	}
+}
+
+func TestReadErrors(t *testing.T) {
+	handler := NewHandler(Options{MaxBodySize: 64})
+
+	for _, entry := range []struct {
+		Method      string
+		ContentType string
+		Body        string
+		Status      int
+	}{
+		{http.MethodGet, "text/plain", "", http.StatusMethodNotAllowed},
+		{http.MethodPost, "application/pdf", "%PDF", http.StatusUnsupportedMediaType},
+		{http.MethodPost, "text/plain", strings.Repeat("a", 65), http.StatusRequestEntityTooLarge},
+		{http.MethodPost, "message/rfc822", "not a message", http.StatusBadRequest},
+	} {
+		request := httptest.NewRequest(entry.Method, "/read", strings.NewReader(entry.Body))
+		request.Header.Set("Content-Type", entry.ContentType)
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+
+		response := map[string]string{}
+
+		if recorder.Code != entry.Status || json.Unmarshal(recorder.Body.Bytes(), &response) != nil || len(response["error"]) == 0 {
+			t.Error(entry.Method, entry.ContentType, "unexpected response", recorder.Code, recorder.Body.String())
+		}
+	}
+}
+
+func TestHealthAndMetrics(t *testing.T) {
+	server := httptest.NewServer(NewHandler(Options{}))
+	defer server.Close()
+
+	response, err := http.Get(server.URL + "/healthz")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		t.Error("unexpected health status", response.StatusCode)
+	}
+
+	for _, body := range []string{_Fixture(t, "gmail_en_body.txt"), _Fixture(t, "apple_mail_de_body.txt"), "Praesent suscipit egestas hendrerit."} {
+		response, err := http.Post(server.URL+"/read", "text/plain", strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		response.Body.Close()
+	}
+
+	response, err = http.Get(server.URL + "/metrics")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer response.Body.Close()
+
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	metrics := string(data)
+
+	for _, line := range []string{
+		`efp_http_requests_total{path="/healthz",status="200"} 1`,
+		`efp_http_requests_total{path="/read",status="200"} 3`,
+		`efp_reads_total{type="text",forwarded="true"} 2`,
+		`efp_reads_total{type="text",forwarded="false"} 1`,
+		`efp_forwards_total{client="unknown",locale=""} 1`,
+		`efp_forwards_total{client="Apple Mail",locale="de"} 1`,
+		`efp_forwards_by_confidence_total{confidence="high"} 2`,
+		`efp_read_duration_seconds_count 3`,
+	} {
+		if !strings.Contains(metrics, line+"\n") {
+			t.Error("missing", line, "in", metrics)
+		}
+	}
+}
+
+func TestReadLimits(t *testing.T) {
+	parser, err := efp.NewParser(efp.DefaultPatternSet())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	handler := NewHandler(Options{Parser: parser.WithLimits(efp.Limits{MaxBodySize: 64})})
+
+	for mediaType, fixture := range map[string]string{
+		"text/plain":     "gmail_en_body.txt",
+		"text/html":      "gmail_en_body.html",
+		"message/rfc822": "gmail_en_body.txt",
+	} {
+		recorder, _ := _Post(t, handler, "/read", mediaType, _Fixture(t, fixture))
+
+		if recorder.Code != http.StatusRequestEntityTooLarge || !strings.Contains(recorder.Body.String(), "exceeds the limit of 64 bytes") {
+			t.Error(mediaType, "unexpected response", recorder.Code, recorder.Body.String())
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package emailforwardparser
+
+// WithSignatures returns a copy of the parser that splits the signature and
+// disclaimers of the forwarder off the end of ReadResult.Message, into
+// ReadResult.Signature. They start at the first line matching a Signature
+// pattern: a "-- " delimiter, a mobile tagline such as "Sent from my
+// iPhone", or the opening of a legal disclaimer.
+func (parser *Parser) WithSignatures() *Parser {
+	signed := *parser
+	signed.signatures = true
+
+	return &signed
+}
+
+func (parser *Parser) _SplitSignature(result _ParseBodyResult) _ParseBodyResult {
+	if len(result.Message) == 0 {
+		return result
+	}
+
+	parser.trace._SetStage("Signature")
+
+	match, _ := parser._SplitPatterns(parser.patterns.Signature, result.Message)
+
+	if len(match) < 2 {
+		return result
+	}
+
+	// The position of the signature in Message, then in Body
+	index := len(match[0])
+	start := result.MessageLoc[0] + index
+
+	result.Signature = trimString(result.Message[index:])
+	result.Message = trimString(result.Message[:index])
+
+	result.SignatureLoc = _TrimmedLoc(result.Body, start, result.MessageLoc[1])
+	result.MessageLoc = _TrimmedLoc(result.Body, result.MessageLoc[0], start)
+
+	return result
+}
@@ -0,0 +1,91 @@
+package emailforwardparser
+
+import (
+	"testing"
+)
+
+func TestWithSignatures(t *testing.T) {
+	email, _ := _Read("gmail_en_body", "")
+	parser := _DefaultParser.WithSignatures()
+
+	for _, test := range []struct {
+		Message   string
+		Signature string
+	}{
+		{"Praesent suscipit egestas hendrerit.\n\n-- \nJohn Doe\nAcme", "-- \nJohn Doe\nAcme"},
+		{"Praesent suscipit egestas hendrerit.\n\nSent from my iPhone", "Sent from my iPhone"},
+		{"Praesent suscipit egestas hendrerit.\n\nVon meinem iPhone gesendet", "Von meinem iPhone gesendet"},
+		{"Praesent suscipit egestas hendrerit.\n\nWysłane z iPhone’a", "Wysłane z iPhone’a"},
+		{"Praesent suscipit egestas hendrerit.\n\nJohn Doe\n\nCONFIDENTIALITY NOTICE: this email is confidential.", "CONFIDENTIALITY NOTICE: this email is confidential."},
+		{"Praesent suscipit egestas hendrerit.\n--\nJohn Doe\n\nThis e-mail and any attachments are confidential.", "--\nJohn Doe\n\nThis e-mail and any attachments are confidential."},
+		{"Praesent suscipit egestas hendrerit, see the disclaimer below.", ""},
+
+		// A mobile tagline, and a legal footer, in each locale
+		{"Praesent suscipit egestas hendrerit.\n\nOdesláno z chytrého telefonu Samsung Galaxy.", "Odesláno z chytrého telefonu Samsung Galaxy."},
+		{"Praesent suscipit egestas hendrerit.\n\nJohn Doe\n\nTento e-mail a jeho přílohy jsou důvěrné.", "Tento e-mail a jeho přílohy jsou důvěrné."},
+		{"Praesent suscipit egestas hendrerit.\n\nHent Outlook til Android", "Hent Outlook til Android"},
+		{"Praesent suscipit egestas hendrerit.\n\nJohn Doe\n\nDenne e-mail kan indeholde fortrolige oplysninger.", "Denne e-mail kan indeholde fortrolige oplysninger."},
+		{"Praesent suscipit egestas hendrerit.\n\nOutlook für Android herunterladen", "Outlook für Android herunterladen"},
+		{"Praesent suscipit egestas hendrerit.\n\nVon meinem Samsung Galaxy gesendet", "Von meinem Samsung Galaxy gesendet"},
+		{"Praesent suscipit egestas hendrerit.\n\nSent from my Galaxy", "Sent from my Galaxy"},
+		{"Praesent suscipit egestas hendrerit.\n\nGet Outlook for iOS", "Get Outlook for iOS"},
+		{"Praesent suscipit egestas hendrerit.\n\nEnviado desde mi smartphone Samsung Galaxy.", "Enviado desde mi smartphone Samsung Galaxy."},
+		{"Praesent suscipit egestas hendrerit.\n\nSaadetud minu Android-seadmest", "Saadetud minu Android-seadmest"},
+		{"Praesent suscipit egestas hendrerit.\n\nJohn Doe\n\nSee e-kiri on konfidentsiaalne.", "See e-kiri on konfidentsiaalne."},
+		{"Praesent suscipit egestas hendrerit.\n\nHanki Outlook for Android", "Hanki Outlook for Android"},
+		{"Praesent suscipit egestas hendrerit.\n\nJohn Doe\n\nTämä sähköpostiviesti ja sen liitteet ovat luottamuksellisia.", "Tämä sähköpostiviesti ja sen liitteet ovat luottamuksellisia."},
+		{"Praesent suscipit egestas hendrerit.\n\nObtenir Outlook pour Android", "Obtenir Outlook pour Android"},
+		{"Praesent suscipit egestas hendrerit.\n\nPoslano s mog Samsung Galaxy pametnog telefona.", "Poslano s mog Samsung Galaxy pametnog telefona."},
+		{"Praesent suscipit egestas hendrerit.\n\nJohn Doe\n\nOva poruka i svi privitci su povjerljivi.", "Ova poruka i svi privitci su povjerljivi."},
+		{"Praesent suscipit egestas hendrerit.\n\nAz Outlook for Android letöltése", "Az Outlook for Android letöltése"},
+		{"Praesent suscipit egestas hendrerit.\n\nJohn Doe\n\nEz az e-mail és annak mellékletei bizalmasak.", "Ez az e-mail és annak mellékletei bizalmasak."},
+		{"Praesent suscipit egestas hendrerit.\n\nInviato da smartphone Samsung Galaxy.", "Inviato da smartphone Samsung Galaxy."},
+		{"Praesent suscipit egestas hendrerit.\n\nJohn Doe\n\nQuesto messaggio e gli eventuali allegati sono riservati.", "Questo messaggio e gli eventuali allegati sono riservati."},
+		{"Praesent suscipit egestas hendrerit.\n\niOS 版 Outlook を入手", "iOS 版 Outlook を入手"},
+		{"Praesent suscipit egestas hendrerit.\n\nJohn Doe\n\nこのメールには機密情報が含まれています。", "このメールには機密情報が含まれています。"},
+		{"Praesent suscipit egestas hendrerit.\n\nVerzonden vanaf mijn Android-telefoon", "Verzonden vanaf mijn Android-telefoon"},
+		{"Praesent suscipit egestas hendrerit.\n\nJohn Doe\n\nDit bericht en eventuele bijlagen zijn vertrouwelijk.", "Dit bericht en eventuele bijlagen zijn vertrouwelijk."},
+		{"Praesent suscipit egestas hendrerit.\n\nLast ned Outlook for iOS", "Last ned Outlook for iOS"},
+		{"Praesent suscipit egestas hendrerit.\n\nJohn Doe\n\nDenne e-posten kan inneholde konfidensiell informasjon.", "Denne e-posten kan inneholde konfidensiell informasjon."},
+		{"Praesent suscipit egestas hendrerit.\n\nPobierz program Outlook dla systemu Android", "Pobierz program Outlook dla systemu Android"},
+		{"Praesent suscipit egestas hendrerit.\n\nJohn Doe\n\nTa wiadomość wraz z załącznikami jest poufna.", "Ta wiadomość wraz z załącznikami jest poufna."},
+		{"Praesent suscipit egestas hendrerit.\n\nEnviado do meu smartphone Samsung Galaxy.", "Enviado do meu smartphone Samsung Galaxy."},
+		{"Praesent suscipit egestas hendrerit.\n\nJohn Doe\n\nEsta mensagem e seus anexos são confidenciais.", "Esta mensagem e seus anexos são confidenciais."},
+		{"Praesent suscipit egestas hendrerit.\n\nObter o Outlook para Android", "Obter o Outlook para Android"},
+		{"Praesent suscipit egestas hendrerit.\n\nTrimis de pe smartphone-ul meu Samsung Galaxy.", "Trimis de pe smartphone-ul meu Samsung Galaxy."},
+		{"Praesent suscipit egestas hendrerit.\n\nJohn Doe\n\nAcest e-mail este confidențial.", "Acest e-mail este confidențial."},
+		{"Praesent suscipit egestas hendrerit.\n\nОтправлено с устройства Android", "Отправлено с устройства Android"},
+		{"Praesent suscipit egestas hendrerit.\n\nJohn Doe\n\nЭто сообщение и любые вложения к нему являются конфиденциальными.", "Это сообщение и любые вложения к нему являются конфиденциальными."},
+		{"Praesent suscipit egestas hendrerit.\n\nZískať Outlook pre Android", "Získať Outlook pre Android"},
+		{"Praesent suscipit egestas hendrerit.\n\nJohn Doe\n\nTento e-mail a jeho prílohy sú dôverné.", "Tento e-mail a jeho prílohy sú dôverné."},
+		{"Praesent suscipit egestas hendrerit.\n\nSkickat från min Samsung Galaxy-smartphone.", "Skickat från min Samsung Galaxy-smartphone."},
+		{"Praesent suscipit egestas hendrerit.\n\nJohn Doe\n\nDetta e-postmeddelande och eventuella bilagor är konfidentiella.", "Detta e-postmeddelande och eventuella bilagor är konfidentiella."},
+		{"Praesent suscipit egestas hendrerit.\n\nAndroid için Outlook’u edinin", "Android için Outlook’u edinin"},
+		{"Praesent suscipit egestas hendrerit.\n\nJohn Doe\n\nBu e-posta ve ekleri gizlidir.", "Bu e-posta ve ekleri gizlidir."},
+		{"Praesent suscipit egestas hendrerit.\n\nНадіслано зі смартфона Samsung Galaxy.", "Надіслано зі смартфона Samsung Galaxy."},
+		{"Praesent suscipit egestas hendrerit.\n\nJohn Doe\n\nЦе повідомлення містить конфіденційну інформацію.", "Це повідомлення містить конфіденційну інформацію."},
+	} {
+		body := test.Message + "\n\n" + email
+		result := parser.ReadWithSpans(body, "")
+
+		if result.Signature != test.Signature || result.Message != trimString(test.Message[:len(test.Message)-len(test.Signature)]) {
+			t.Errorf("unexpected message %q and signature %q", result.Message, result.Signature)
+		}
+
+		if span := result.Spans.Signature; body[span.Start:span.End] != result.Signature {
+			t.Errorf("unexpected signature span %q", body[span.Start:span.End])
+		}
+
+		if span := result.Spans.Message; body[span.Start:span.End] != result.Message {
+			t.Errorf("unexpected message span %q", body[span.Start:span.End])
+		}
+
+		if result.Email.From.Address != _TestFromAddress {
+			t.Error("unexpected sender", result.Email.From)
+		}
+
+		if Read(body, "").Message != trimString(test.Message) {
+			t.Error("the signature should only be split by WithSignatures")
+		}
+	}
+}
@@ -0,0 +1,221 @@
+package emailforwardparser
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	regexp "github.com/wasilibs/go-re2"
+)
+
+// Span is the position of a part of a forward in the body given to
+// ReadWithSpans, as byte offsets (Start, End) and rune offsets (RuneStart,
+// RuneEnd). It is zero if the part was not found.
+type Span struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+
+	RuneStart int `json:"runeStart"`
+	RuneEnd   int `json:"runeEnd"`
+}
+
+// ReadSpans holds the positions of the parts of a forward. Header spans
+// cover the whole header line, label included; when the sender and date are
+// only written in the separator (as Outlook 2019 does), From and Date are
+// the separator span.
+type ReadSpans struct {
+	Message   Span `json:"message"`
+	Signature Span `json:"signature"`
+	Separator Span `json:"separator"`
+
+	From       Span `json:"from"`
+	To         Span `json:"to"`
+	CC         Span `json:"cc"`
+	BCC        Span `json:"bcc"`
+	ReplyTo    Span `json:"replyTo"`
+	Subject    Span `json:"subject"`
+	Date       Span `json:"date"`
+	MessageID  Span `json:"messageId"`
+	InReplyTo  Span `json:"inReplyTo"`
+	References Span `json:"references"`
+
+	Body Span `json:"body"`
+}
+
+func _NewReadSpans(input string, body _ParseBodyResult, email _ParseOriginalEmailResult) *ReadSpans {
+	spans := &ReadSpans{
+		Message:   _MakeSpan(input, body.Offsets, body.MessageLoc),
+		Signature: _MakeSpan(input, body.Offsets, body.SignatureLoc),
+		Separator: _MakeSpan(input, body.Offsets, body.SeparatorLoc),
+
+		Body: _MakeSpan(input, email.Offsets, email.BodyLoc),
+	}
+
+	for _, header := range []struct {
+		Span    *Span
+		Pattern *regexp.Regexp
+	}{
+		{&spans.From, email.Headers.From},
+		{&spans.To, email.Headers.To},
+		{&spans.CC, email.Headers.CC},
+		{&spans.BCC, email.Headers.BCC},
+		{&spans.ReplyTo, email.Headers.ReplyTo},
+		{&spans.Subject, email.Headers.Subject},
+		{&spans.Date, email.Headers.Date},
+		{&spans.MessageID, email.Headers.MessageID},
+		{&spans.InReplyTo, email.Headers.InReplyTo},
+		{&spans.References, email.Headers.References},
+	} {
+		if header.Pattern == nil {
+			continue
+		}
+
+		// Lax patterns match a copy of the header block without some of its
+		// headers, so they are looked up again in the header block itself
+		if loc := header.Pattern.FindStringIndex(email.Text); loc != nil {
+			*header.Span = _MakeSpan(input, email.Offsets, _TrimmedLoc(email.Text, loc[0], loc[1]))
+		}
+	}
+
+	if email.Headers.From == nil && (len(email.From.Name) > 0 || len(email.From.Address) > 0) {
+		spans.From = spans.Separator
+	}
+
+	if email.Headers.Date == nil && len(email.Date) > 0 {
+		spans.Date = spans.Separator
+	}
+
+	return spans
+}
+
+// _MakeSpan returns the span in input of text[loc[0]:loc[1]], the bytes of
+// text being at offsets in input
+func _MakeSpan(input string, offsets []int, loc []int) Span {
+	if offsets == nil || loc == nil || loc[0] >= loc[1] {
+		return Span{}
+	}
+
+	start := offsets[loc[0]]
+	end := offsets[loc[1]-1] + 1
+
+	// The last byte may stand for a longer rune, such as a non-breaking
+	// space turned into a space
+	for end < len(input) && !utf8.RuneStart(input[end]) {
+		end++
+	}
+
+	return Span{
+		Start: start,
+		End:   end,
+
+		RuneStart: utf8.RuneCountInString(input[:start]),
+		RuneEnd:   utf8.RuneCountInString(input[:end]),
+	}
+}
+
+// _TrimmedLoc returns the position of s[start:end] without its leading and
+// trailing spaces, as trimString would return it
+func _TrimmedLoc(s string, start int, end int) []int {
+	trimmed := strings.TrimLeftFunc(s[start:end], unicode.IsSpace)
+	start = end - len(trimmed)
+	end = start + len(strings.TrimRightFunc(trimmed, unicode.IsSpace))
+
+	return []int{start, end}
+}
+
+// _SuffixLoc returns the position of suffix, a trimmed end of s
+func _SuffixLoc(s string, suffix string) []int {
+	if suffix == s {
+		return []int{0, len(s)}
+	}
+
+	end := len(strings.TrimRightFunc(s, unicode.IsSpace))
+	start := end - len(suffix)
+
+	if start < 0 || s[start:end] != suffix {
+		return nil
+	}
+
+	return []int{start, end}
+}
+
+func _IdentityOffsets(length int) []int {
+	offsets := make([]int, length+1)
+
+	for i := range offsets {
+		offsets[i] = i
+	}
+
+	return offsets
+}
+
+// _ReplaceAllMapped is re.ReplaceAllString(s, replacement) that also maps
+// the bytes of the result to the input of Read, given the offsets of the
+// bytes of s; it does not map them when offsets is nil.
+func _ReplaceAllMapped(re *regexp.Regexp, s string, replacement string, offsets []int) (string, []int) {
+	if literal, ok := _Literals[re]; ok && !strings.Contains(s, literal) {
+		return s, offsets
+	}
+
+	if offsets == nil {
+		return re.ReplaceAllString(s, replacement), nil
+	}
+
+	return _ReplaceLocs(s, re.FindAllStringIndex(s, -1), replacement, offsets)
+}
+
+// _PreprocessMapped is preprocessString that also maps the bytes of the
+// result, as _ReplaceAllMapped
+func _PreprocessMapped(s string, offsets []int) (string, []int) {
+	if offsets == nil {
+		return preprocessString(s), nil
+	}
+
+	trimmed := strings.TrimLeftFunc(s, _IsNotGraphic)
+	start := len(s) - len(trimmed)
+	end := start + len(strings.TrimRightFunc(trimmed, _IsNotGraphic))
+
+	s, offsets = s[start:end], offsets[start:end+1]
+
+	locs := [][]int{}
+
+	for i := 0; i < len(s); {
+		index := strings.Index(s[i:], "\uFEFF")
+		if index < 0 {
+			break
+		}
+
+		locs = append(locs, []int{i + index, i + index + len("\uFEFF")})
+		i += index + len("\uFEFF")
+	}
+
+	return _ReplaceLocs(s, locs, "", offsets)
+}
+
+func _ReplaceLocs(s string, locs [][]int, replacement string, offsets []int) (string, []int) {
+	if len(locs) == 0 {
+		return s, offsets
+	}
+
+	result := strings.Builder{}
+	mapped := make([]int, 0, len(offsets))
+	previous := 0
+
+	for _, loc := range locs {
+		result.WriteString(s[previous:loc[0]])
+		result.WriteString(replacement)
+
+		mapped = append(mapped, offsets[previous:loc[0]]...)
+
+		for range []byte(replacement) {
+			mapped = append(mapped, offsets[loc[0]])
+		}
+
+		previous = loc[1]
+	}
+
+	result.WriteString(s[previous:])
+	mapped = append(mapped, offsets[previous:]...)
+
+	return result.String(), mapped
+}
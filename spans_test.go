@@ -0,0 +1,112 @@
+package emailforwardparser
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func _SpanText(input string, span Span) string {
+	return input[span.Start:span.End]
+}
+
+func TestReadWithSpans(t *testing.T) {
+	email, _ := _Read("gmail_en_body", "")
+	email = "Grüße,\n" + _TestMessage + "\n\n" + email
+
+	result := ReadWithSpans(email, "")
+
+	_TestEmail(t, result, "gmail_en_body", false, false, false, true, false)
+
+	for _, entry := range []struct {
+		Name string
+		Span Span
+		Text string
+	}{
+		{"Message", result.Spans.Message, "Grüße,\n" + _TestMessage},
+		{"Separator", result.Spans.Separator, "---------- Forwarded message ---------"},
+		{"From", result.Spans.From, "From: John Doe <john.doe@acme.com>"},
+		{"To", result.Spans.To, "To: <bessie.berry@acme.com>"},
+		{"CC", result.Spans.CC, "Cc: Walter Sheltan <walter.sheltan@acme.com>, Nicholas <nicholas@globex.corp>"},
+		{"Subject", result.Spans.Subject, "Subject: " + _TestSubject},
+		{"Date", result.Spans.Date, "Date: Wed, Oct 27, 2021 at 9:31 AM"},
+		{"Body", result.Spans.Body, _TestBody},
+	} {
+		if _SpanText(email, entry.Span) != entry.Text {
+			t.Errorf("%s: unexpected span text %q", entry.Name, _SpanText(email, entry.Span))
+		}
+	}
+
+	if result.Spans.Separator.RuneStart != result.Spans.Separator.Start-2 {
+		t.Error("unexpected rune offset", result.Spans.Separator)
+	}
+
+	if result.Spans.BCC != (Span{}) {
+		t.Error("unexpected BCC span", result.Spans.BCC)
+	}
+}
+
+func TestReadWithSpansQuoted(t *testing.T) {
+	email, _ := _Read("apple_mail_de_body_variant_16", "")
+	email = "\uFEFF" + strings.ReplaceAll(email, "\n", "\r\n")
+
+	result := ReadWithSpans(email, "")
+
+	if text := _SpanText(email, result.Spans.Body); !strings.HasPrefix(text, "Aenean quis") || !strings.HasSuffix(text, "sagittis eget.") || !strings.Contains(text, "\r\n> Sed nec") {
+		t.Errorf("unexpected body span text %q", text)
+	}
+
+	if text := _SpanText(email, result.Spans.BCC); text != "Blindkopie: Suzanne <suzanne@globex.corp>" {
+		t.Errorf("unexpected BCC span text %q", text)
+	}
+
+	if text := _SpanText(email, result.Spans.Separator); text != "> Anfang der weitergeleiteten Nachricht:" {
+		t.Errorf("unexpected separator span text %q", text)
+	}
+}
+
+func TestReadWithSpansSeparatorInformation(t *testing.T) {
+	email, _ := _Read("outlook_2019_en_body", "")
+
+	result := ReadWithSpans(email, "")
+
+	if result.Spans.From != result.Spans.Separator || result.Spans.Date != result.Spans.Separator || result.Spans.Separator == (Span{}) {
+		t.Error("unexpected spans", result.Spans.From, result.Spans.Date, result.Spans.Separator)
+	}
+}
+
+func TestReadWithSpansFixtures(t *testing.T) {
+	entries, err := os.ReadDir("fixtures")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, entry := range entries {
+		name := strings.TrimSuffix(entry.Name(), ".txt")
+
+		if !strings.Contains(name, "_body") || name == entry.Name() {
+			continue
+		}
+
+		email, _ := _Read(name, "")
+
+		result := ReadWithSpans(email, "")
+		spans := result.Spans
+		result.Spans = nil
+
+		if !reflect.DeepEqual(result, Read(email, "")) {
+			t.Error(name, "ReadWithSpans and Read differ")
+		}
+
+		for _, span := range []Span{spans.Message, spans.Separator, spans.From, spans.To, spans.CC, spans.Subject, spans.Date, spans.Body} {
+			if span.Start < 0 || span.End < span.Start || span.End > len(email) || span.RuneEnd-span.RuneStart > span.End-span.Start {
+				t.Error(name, "invalid span", span)
+			}
+		}
+
+		if len(result.Email.Body) > 0 && spans.Body == (Span{}) {
+			t.Error(name, "missing body span")
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package emailforwardparser
+
+import (
+	"strings"
+
+	regexp "github.com/wasilibs/go-re2"
+)
+
+// A mailing list tag, such as "[team]"
+var _ListTag = regexp.MustCompile(`^\[[^\[\]:]*\]`)
+
+// NormalizeSubject removes the forward and reply prefixes of a subject, in
+// any supported locale and case, however deeply nested ("Fwd: FW: re: WG:
+// Budget"), along with bracketed forwards ("[Fwd: Budget]") and the mailing
+// list tags before a prefix ("[team] Re: Budget"). Single-letter prefixes,
+// such as the Italian "I:", keep their case. It returns the subject left,
+// and the prefixes removed in order, such as ["Fwd:", "FW:", "Re:", "WG:"].
+func NormalizeSubject(subject string) (string, []string) {
+	return _DefaultParser.NormalizeSubject(subject)
+}
+
+// NormalizeSubject is like the package-level NormalizeSubject, using the
+// forward (Subject) and reply (ReplySubject) prefixes of the parser's
+// patterns, ignoring case.
+func (parser *Parser) NormalizeSubject(subject string) (string, []string) {
+	subject = trimString(preprocessString(subject))
+	prefixes := []string{}
+
+	for {
+		// "[Fwd: Budget]", written by some clients when forwarding
+		if strings.HasPrefix(subject, "[") && _ClosingBracket(subject) == len(subject)-1 {
+			inner := trimString(subject[1 : len(subject)-1])
+
+			if prefix, rest := parser._SubjectPrefix(inner); len(prefix) > 0 {
+				prefixes = append(prefixes, prefix)
+				subject = rest
+
+				continue
+			}
+		}
+
+		// A tag before a prefix, rather than one of the subject ("[PATCH v2]")
+		if tag := _ListTag.FindString(subject); len(tag) > 0 && parser._StartsWithPrefix(trimString(subject[len(tag):])) {
+			prefixes = append(prefixes, tag)
+			subject = trimString(subject[len(tag):])
+
+			continue
+		}
+
+		prefix, rest := parser._SubjectPrefix(subject)
+		if len(prefix) == 0 {
+			return subject, prefixes
+		}
+
+		prefixes = append(prefixes, prefix)
+		subject = rest
+	}
+}
+
+// _SubjectPrefix returns the forward or reply prefix at the start of
+// subject, and the subject after it
+func (parser *Parser) _SubjectPrefix(subject string) (string, string) {
+	for _, re := range parser.patterns.SubjectPrefixes {
+		loc := re.FindStringSubmatchIndex(subject)
+
+		// The prefix must start the subject, and be followed by the captured
+		// subject
+		if len(loc) < 4 || loc[0] != 0 || loc[2] <= 0 {
+			continue
+		}
+
+		return trimString(subject[:loc[2]]), trimString(subject[loc[2]:])
+	}
+
+	return "", subject
+}
+
+// _StartsWithPrefix tells whether subject starts with a forward or reply
+// prefix, or a bracketed forward, after any mailing list tags
+func (parser *Parser) _StartsWithPrefix(subject string) bool {
+	for {
+		if strings.HasPrefix(subject, "[") && _ClosingBracket(subject) == len(subject)-1 {
+			if prefix, _ := parser._SubjectPrefix(trimString(subject[1 : len(subject)-1])); len(prefix) > 0 {
+				return true
+			}
+		}
+
+		tag := _ListTag.FindString(subject)
+		if len(tag) == 0 {
+			prefix, _ := parser._SubjectPrefix(subject)
+
+			return len(prefix) > 0
+		}
+
+		subject = trimString(subject[len(tag):])
+	}
+}
+
+// _ClosingBracket returns the index of the bracket closing the one s starts
+// with, or -1
+func _ClosingBracket(s string) int {
+	depth := 0
+
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '[':
+			depth++
+		case ']':
+			depth--
+
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
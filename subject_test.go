@@ -0,0 +1,64 @@
+package emailforwardparser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeSubject(t *testing.T) {
+	for _, test := range []struct {
+		Subject  string
+		Expected string
+		Prefixes []string
+	}{
+		{"Budget", "Budget", []string{}},
+		{"Fwd: Budget", "Budget", []string{"Fwd:"}},
+		{"Fwd: FW: Re: WG: Budget", "Budget", []string{"Fwd:", "FW:", "Re:", "WG:"}},
+		{"AW: SV: Odp: TR: Budget", "Budget", []string{"AW:", "SV:", "Odp:", "TR:"}},
+		{"  Re:Re:  Budget ", "Budget", []string{"Re:", "Re:"}},
+		{"[Fwd: Budget]", "Budget", []string{"Fwd:"}},
+		{"[team] Re: [Fwd: Budget]", "Budget", []string{"[team]", "Re:", "Fwd:"}},
+		{"Re: [team] [Fwd: [ops] Re: Budget]", "Budget", []string{"Re:", "[team]", "Fwd:", "[ops]", "Re:"}},
+		{"[team] [ops] Re: Budget", "Budget", []string{"[team]", "[ops]", "Re:"}},
+		{"Re: [ops] Budget", "[ops] Budget", []string{"Re:"}},
+		{"[PATCH v2] fix", "[PATCH v2] fix", []string{}},
+		{"[1/3] Budget", "[1/3] Budget", []string{}},
+		{"[team] Budget", "[team] Budget", []string{}},
+		{"[Fwd: Budget] and more", "[Fwd: Budget] and more", []string{}},
+		{"Budget [team]", "Budget [team]", []string{}},
+		{"Review: Budget", "Review: Budget", []string{}},
+		{"FWD: Budget", "Budget", []string{"FWD:"}},
+		{"re: Budget", "Budget", []string{"re:"}},
+		{"Sv: Budget", "Budget", []string{"Sv:"}},
+		{"Aw: Budget", "Budget", []string{"Aw:"}},
+		{"VÁ: Budget", "Budget", []string{"VÁ:"}},
+		{"vá: fw: Budget", "Budget", []string{"vá:", "fw:"}},
+		{"I: Budget", "Budget", []string{"I:"}},
+		{"R: Budget", "Budget", []string{"R:"}},
+		{"i: think so", "i: think so", []string{}},
+		{"r: the language", "r: the language", []string{}},
+		{"Fwd:", "", []string{"Fwd:"}},
+		{"[Budget: Q4]", "[Budget: Q4]", []string{}},
+	} {
+		subject, prefixes := NormalizeSubject(test.Subject)
+
+		if subject != test.Expected || !reflect.DeepEqual(prefixes, test.Prefixes) {
+			t.Errorf("%q: unexpected subject %q and prefixes %q", test.Subject, subject, prefixes)
+		}
+	}
+}
+
+func TestNormalizeSubjectFixtures(t *testing.T) {
+	for _, fixture := range _ReadFixtures(t) {
+		if len(fixture.Subject) == 0 {
+			continue
+		}
+
+		subject, prefixes := NormalizeSubject(fixture.Subject)
+
+		// Forwards and replies of the same email, under a single prefix
+		if subject != "Integer consequat non purus" || len(prefixes) != 1 {
+			t.Errorf("%s: unexpected subject %q and prefixes %q", fixture.Name, subject, prefixes)
+		}
+	}
+}
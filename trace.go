@@ -0,0 +1,147 @@
+package emailforwardparser
+
+import (
+	"fmt"
+	"strings"
+
+	regexp "github.com/wasilibs/go-re2"
+)
+
+// Trace records the patterns tried while reading a forward, in order.
+type Trace struct {
+	Events []TraceEvent `json:"events"`
+
+	stage string
+}
+
+// TraceEvent is a pattern tried on a text. Start and End are the byte
+// offsets of the match in that text (the body, the header block, or a single
+// header value, depending on the stage), or -1 if the pattern did not match.
+// Of the patterns of a field tried together, the match closest to the top of
+// the text is selected; Reason tells why a match was not.
+type TraceEvent struct {
+	// Stage is the part of the forward being looked for, such as "Body"
+	// (the separator), "OriginalBody" (the end of the header block) or
+	// "OriginalFrom"
+	Stage string `json:"stage"`
+	// Field is the PatternSet field of the pattern
+	Field   string  `json:"field"`
+	Pattern Pattern `json:"pattern"`
+
+	Matched bool   `json:"matched"`
+	Start   int    `json:"start"`
+	End     int    `json:"end"`
+	Match   string `json:"match"`
+
+	Selected bool   `json:"selected"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// String renders the trace as a table, one pattern per line.
+func (trace *Trace) String() string {
+	lines := make([]string, 0, len(trace.Events))
+
+	for _, event := range trace.Events {
+		status := "no match"
+
+		if event.Matched {
+			status = fmt.Sprintf("match %d-%d", event.Start, event.End)
+		}
+
+		if event.Selected {
+			status += ", selected"
+		}
+
+		if len(event.Reason) > 0 {
+			status += ", " + event.Reason
+		}
+
+		lines = append(lines, fmt.Sprintf("%-18s %-24s %-40s %s", event.Stage, event.Field, status, event.Pattern.Expr))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// ReadWithTrace is like Read, and also returns the patterns tried.
+func ReadWithTrace(body string, subject string) (ReadResult, *Trace) {
+	return _DefaultParser.ReadWithTrace(body, subject)
+}
+
+// ReadWithTrace is like the package-level ReadWithTrace, using the parser's
+// patterns.
+func (parser *Parser) ReadWithTrace(body string, subject string) (ReadResult, *Trace) {
+	trace := &Trace{Events: []TraceEvent{}}
+	traced := *parser
+	traced.trace = trace
+
+	result, _ := traced._Read(body, subject, false)
+
+	return result, trace
+}
+
+func (trace *Trace) _SetStage(stage string) {
+	if trace != nil {
+		trace.stage = stage
+	}
+}
+
+// _Record adds an event for each of regexes tried on str, selected being the
+// pattern _LoopRegexes* picked
+func (parser *Parser) _Record(regexes []*regexp.Regexp, str string, selected *regexp.Regexp) {
+	trace := parser.trace
+
+	selectedStart := -1
+
+	if selected != nil {
+		selectedStart = selected.FindStringIndex(str)[0]
+	}
+
+	for _, re := range regexes {
+		event := TraceEvent{
+			Stage:   trace.stage,
+			Field:   parser.patterns.Names[re],
+			Pattern: parser.patterns.Definitions[re],
+
+			Start: -1,
+			End:   -1,
+		}
+
+		if loc := re.FindStringIndex(str); loc != nil {
+			event.Matched = true
+			event.Start, event.End = loc[0], loc[1]
+			event.Match = str[loc[0]:loc[1]]
+		}
+
+		switch {
+		case re == selected:
+			event.Selected = true
+		case !event.Matched:
+		case event.Start > selectedStart:
+			event.Reason = "another pattern matched closer to the top"
+		default:
+			event.Reason = "a previous pattern matched at the same position"
+		}
+
+		trace.Events = append(trace.Events, event)
+	}
+}
+
+// _Reject unselects the last event of pattern, for the given reason
+func (parser *Parser) _Reject(pattern *regexp.Regexp, reason string) {
+	if parser.trace == nil || pattern == nil {
+		return
+	}
+
+	expr := pattern.String()
+
+	for i := len(parser.trace.Events) - 1; i >= 0; i-- {
+		event := &parser.trace.Events[i]
+
+		if event.Selected && event.Pattern.Expr == expr {
+			event.Selected = false
+			event.Reason = reason
+
+			return
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package emailforwardparser
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func _FindTraceEvent(trace *Trace, stage string, field string, fn func(TraceEvent) bool) *TraceEvent {
+	for i, event := range trace.Events {
+		if event.Stage == stage && event.Field == field && fn(event) {
+			return &trace.Events[i]
+		}
+	}
+
+	return nil
+}
+
+func TestReadWithTrace(t *testing.T) {
+	email, _ := _Read("gmail_en_body", "")
+
+	result, trace := ReadWithTrace(email, "")
+
+	if !reflect.DeepEqual(result, Read(email, "")) {
+		t.Error("ReadWithTrace and Read differ")
+	}
+
+	separator := _FindTraceEvent(trace, "Body", "Separator", func(event TraceEvent) bool { return event.Selected })
+
+	if separator == nil || !strings.HasPrefix(separator.Match, "---------- Forwarded message ---------") || separator.Start != 0 || !strings.Contains(separator.Pattern.Source, "Gmail") {
+		t.Fatal("unexpected separator event", separator)
+	}
+
+	// The subject header is followed by the recipients, not the body
+	subject := _FindTraceEvent(trace, "OriginalBody", "OriginalSubject", func(event TraceEvent) bool { return event.Matched })
+
+	if subject == nil || subject.Selected || !strings.HasPrefix(subject.Reason, "match[3] does not start with") {
+		t.Error("unexpected subject event", subject)
+	}
+
+	cc := _FindTraceEvent(trace, "OriginalBody", "OriginalCC", func(event TraceEvent) bool { return event.Selected })
+
+	if cc == nil {
+		t.Error("missing CC event")
+	}
+
+	if !strings.Contains(trace.String(), "match 0-") {
+		t.Error("unexpected trace", trace.String())
+	}
+}
+
+func TestReadWithTraceNotForwarded(t *testing.T) {
+	_, trace := ReadWithTrace(_TestMessage, "")
+
+	if len(trace.Events) == 0 {
+		t.Fatal("no event")
+	}
+
+	for _, event := range trace.Events {
+		if event.Stage != "Body" || event.Selected || event.Start != -1 {
+			t.Error("unexpected event", event)
+		}
+	}
+
+	_, trace = ReadWithTrace(_TestMessage, _TestSubject)
+
+	for _, event := range trace.Events {
+		if event.Stage != "Subject" || event.Matched {
+			t.Error("unexpected event", event)
+		}
+	}
+}
+
+func TestTraceJSON(t *testing.T) {
+	email, _ := _Read("outlook_2019_en_body", "")
+
+	_, trace := ReadWithTrace(email, "")
+
+	data, err := json.Marshal(trace)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded := &Trace{}
+
+	if err := json.Unmarshal(data, decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(decoded.Events, trace.Events) {
+		t.Error("the trace does not round-trip")
+	}
+
+	if _FindTraceEvent(decoded, "OriginalFrom", "SeparatorWithInformation", func(event TraceEvent) bool { return event.Selected }) == nil {
+		t.Error("missing separator with information event")
+	}
+}
@@ -12,15 +12,17 @@ func trimString(s string) string {
 }
 
 func preprocessString(s string) string {
-	s = strings.TrimFunc(s, func(r rune) bool {
-		return !unicode.IsGraphic(r)
-	})
+	s = strings.TrimFunc(s, _IsNotGraphic)
 
 	s = strings.ReplaceAll(s, "\uFEFF", "")
 
 	return s
 }
 
+func _IsNotGraphic(r rune) bool {
+	return !unicode.IsGraphic(r)
+}
+
 // https://stackoverflow.com/a/53587770/7082789
 func findNamedMatches(pattern *regexp.Regexp, str string) map[string]string {
 	match := pattern.FindStringSubmatch(str)
@@ -36,46 +38,13 @@ func findNamedMatches(pattern *regexp.Regexp, str string) map[string]string {
 func splitWithRegexp(pattern *regexp.Regexp, str string) []string {
 	splitIndices := pattern.FindAllStringSubmatchIndex(str, -1)
 
-	result := []string{}
 	prevIndex := 0
 
 	if len(splitIndices) == 0 {
 		return []string{str}
 	}
 
-	// Remove duplicates
-	newSplitIndices := [][]int{}
-
-	// For each indicie
-	for _, indices := range splitIndices {
-		// Create an empty array
-		newIndicie := []int{}
-
-		// For eeach pair of indicies
-		for i := 0; i < len(indices); i += 2 {
-			// Get the current two indicies
-			ia, ib := indices[i], indices[i+1]
-			// If it's not the first pair
-			if i > 0 {
-				// Get the last two indicies
-				ya, yb := indices[i-2], indices[i-1]
-
-				// If they match, continue
-				if ia == ya && ib == yb {
-					continue
-				}
-			}
-
-			// If all is good, add to new indicie
-			newIndicie = append(newIndicie, ia)
-			newIndicie = append(newIndicie, ib)
-		}
-
-		// Add new indicies to newsplitindicies
-		newSplitIndices = append(newSplitIndices, newIndicie)
-	}
-
-	splitIndices = newSplitIndices
+	result := make([]string, 0, 1+len(splitIndices)*(len(splitIndices[0])/2+1))
 
 	// Because if the match is at index 0 it won't return the whitespace before it like JS does
 	if splitIndices[0][0] == 0 {
@@ -86,6 +55,19 @@ func splitWithRegexp(pattern *regexp.Regexp, str string) []string {
 		for i := 0; i < len(indices); i += 2 {
 			ia, ib := indices[i], indices[i+1]
 
+			// Remove duplicates: a group matching the same text as the
+			// previous pair
+			if i > 0 && ia == indices[i-2] && ib == indices[i-1] {
+				continue
+			}
+
+			// A group that did not participate in the match, which
+			// JavaScript leaves undefined
+			if ia < 0 {
+				result = append(result, "")
+				continue
+			}
+
 			if prevIndex < ia {
 				result = append(result, str[prevIndex:ia])
 			}
@@ -104,11 +86,13 @@ func splitWithRegexp(pattern *regexp.Regexp, str string) []string {
 }
 
 func reconciliateSplitMatch(match []string, minSubstrings int, defaultSubstrings []int, excludeFn func(int) bool) string {
-	str := ""
+	str := strings.Builder{}
 
 	// Add default substrings
 	for _, substr := range defaultSubstrings {
-		str += match[substr]
+		if substr < len(match) {
+			str.WriteString(match[substr])
+		}
 	}
 
 	// More substrings than expected?
@@ -123,10 +107,10 @@ func reconciliateSplitMatch(match []string, minSubstrings int, defaultSubstrings
 			}
 
 			if !exclude {
-				str += match[i]
+				str.WriteString(match[i])
 			}
 		}
 	}
 
-	return str
+	return str.String()
 }